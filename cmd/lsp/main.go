@@ -14,6 +14,7 @@ import (
 	"github.com/nevalang/neva/internal/compiler/desugarer"
 	"github.com/nevalang/neva/internal/compiler/parser"
 	"github.com/nevalang/neva/internal/compiler/sourcecode/typesystem"
+	"github.com/nevalang/neva/internal/runtime/funcs"
 	"github.com/nevalang/neva/pkg"
 )
 
@@ -42,7 +43,7 @@ func main() {
 		builder,
 		p,
 		desugarer.New(),
-		analyzer.MustNew(pkg.Version, resolver),
+		analyzer.MustNew(pkg.Version, resolver, funcs.FuncPorts{}),
 	)
 
 	handler := lspServer.BuildHandler(logger, serverName, indexer)
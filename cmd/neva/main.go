@@ -17,6 +17,7 @@ import (
 	"github.com/nevalang/neva/internal/compiler/irgen"
 	"github.com/nevalang/neva/internal/compiler/parser"
 	"github.com/nevalang/neva/internal/compiler/sourcecode/typesystem"
+	"github.com/nevalang/neva/internal/runtime/funcs"
 	"github.com/nevalang/neva/pkg"
 )
 
@@ -34,7 +35,7 @@ func main() {
 	bldr := builder.MustNew(prsr)
 
 	desugarer := desugarer.New()
-	analyzer := analyzer.MustNew(pkg.Version, resolver)
+	analyzer := analyzer.MustNew(pkg.Version, resolver, funcs.FuncPorts{})
 	irgen := irgen.New()
 
 	golangBackend := golang.NewBackend()
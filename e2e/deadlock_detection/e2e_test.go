@@ -0,0 +1,25 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Second lock waits for signal that can only come after its own data is passed through.
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "--deadlock-timeout", "300ms", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	require.Equal(t, 1, cmd.ProcessState.ExitCode())
+
+	require.Contains(t, string(out), "deadlock: no messages were sent or received for")
+	require.Contains(
+		t,
+		string(out),
+		"pending messages:\n\tin:start[0] -> second/in:data[0] (main -> second): 1 message(s), waiting for",
+	)
+	require.Contains(t, string(out), "busy receivers:\n\tsecond at main/main.neva:2:29 doesn't read second/in:data[0]\n")
+}
@@ -0,0 +1,18 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	require.Equal(t, "", string(out))
+
+	require.Equal(t, 3, cmd.ProcessState.ExitCode())
+}
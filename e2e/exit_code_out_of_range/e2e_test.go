@@ -0,0 +1,31 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Codes that don't fit into process exit status must not wrap around to success.
+func Test(t *testing.T) {
+	tests := []struct {
+		pkg  string
+		want string
+	}{
+		{pkg: "too_big", want: "invalid exit code 256: must be in range 0..255\n"},
+		{pkg: "negative", want: "invalid exit code -1: must be in range 0..255\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			cmd := exec.Command("neva", "run", tt.pkg)
+
+			out, err := cmd.CombinedOutput()
+			require.Error(t, err)
+			require.Equal(t, tt.want, string(out))
+
+			require.Equal(t, 1, cmd.ProcessState.ExitCode())
+		})
+	}
+}
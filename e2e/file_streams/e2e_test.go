@@ -0,0 +1,39 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Lines are read without line endings, copy made by chunks keeps them as is.
+func Test(t *testing.T) {
+	require.NoError(t, os.WriteFile("input.txt", []byte("first\nsecond\r\nthird"), 0644))
+	t.Cleanup(func() {
+		os.Remove("input.txt")
+		os.Remove("copy.txt")
+		os.Remove("all.txt")
+		os.Remove("appended.txt")
+	})
+
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"[\"first\",\"second\",\"third\"]\nfirst\nsecond\r\nthird!\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+	// files created by WriteChunks, WriteAll and Append respectively
+	for _, name := range []string{"copy.txt", "all.txt", "appended.txt"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0644), info.Mode().Perm(), name)
+	}
+}
@@ -0,0 +1,31 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	t.Cleanup(func() {
+		os.RemoveAll("tmp")
+	})
+
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"5\n[\"tmp/a/b/y.txt\"]\n[{\"isDir\":true,\"name\":\"b\"}]\n",
+		string(out),
+	)
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+	// file is removed, directories are left
+	entries, err := os.ReadDir("tmp/a/b")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
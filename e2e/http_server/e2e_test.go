@@ -0,0 +1,52 @@
+package test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	// find free port for the program to listen on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cmd := exec.Command("neva", "run", "main")
+	cmd.Env = append(os.Environ(), "ADDR="+addr)
+	require.NoError(t, cmd.Start())
+	defer cmd.Process.Kill() //nolint:errcheck
+
+	// program must be compiled and listening before we check responses
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Post("http://"+addr+"/echo", "text/plain", strings.NewReader("ping"))
+		return err == nil
+	}, 30*time.Second, 100*time.Millisecond)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "neva", resp.Header.Get("Server"))
+	require.Equal(t, "ping", string(body))
+
+	resp, err = http.Post("http://"+addr+"/echo", "text/plain", strings.NewReader("pong"))
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "pong", string(body))
+
+	require.NoError(t, cmd.Process.Signal(os.Interrupt))
+	require.NoError(t, cmd.Wait())
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
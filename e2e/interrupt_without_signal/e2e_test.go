@@ -0,0 +1,40 @@
+package test
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Program never stops by itself and has no os.Signal nodes,
+// so it's stopped as soon as it has nothing in flight with exit code of the interrupt.
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	r := bufio.NewReader(stdout)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "ready\n", line)
+
+	require.NoError(t, cmd.Process.Signal(os.Interrupt))
+
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Empty(t, string(rest))
+
+	require.Error(t, cmd.Wait())
+	require.Equal(t, "interrupted: interrupt\n", stderr.String())
+	require.Equal(t, 130, cmd.ProcessState.ExitCode())
+}
@@ -0,0 +1,30 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"2\n"+
+			"key not found: durian\n"+
+			"true\n"+
+			"none\n"+
+			`{"apple": 1, "banana": 2, "cherry": 3}`+"\n"+
+			`{"banana": 2}`+"\n"+
+			`{"apple": 1, "banana": 20, "cherry": 3}`+"\n"+
+			`["apple","banana","cherry"]`+"\n"+
+			"[1,20,3]\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
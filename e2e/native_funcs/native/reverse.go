@@ -0,0 +1,47 @@
+package native
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/pkg/runtime"
+)
+
+func init() {
+	runtime.Register("reverse", reverse{})
+}
+
+type reverse struct{}
+
+func (reverse) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var data runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case data = <-dataIn:
+			}
+
+			runes := []rune(data.Str())
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewStrMsg(string(runes)):
+			}
+		}
+	}, nil
+}
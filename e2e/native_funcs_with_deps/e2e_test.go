@@ -0,0 +1,17 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Generated program only has dependencies of the runtime, so native package can't import other modules.
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, _ := cmd.CombinedOutput()
+	require.Contains(t, string(out), "native package imports module that generated program doesn't require")
+	require.Contains(t, string(out), "uuid.go: github.com/google/uuid")
+}
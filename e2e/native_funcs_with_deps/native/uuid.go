@@ -0,0 +1,42 @@
+package native
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nevalang/neva/pkg/runtime"
+)
+
+func init() {
+	runtime.Register("uuid", newID{})
+}
+
+type newID struct{}
+
+func (newID) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	sigIn, err := io.In.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewStrMsg(uuid.NewString()):
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err)
+
+	require.Equal(
+		t,
+		"panic: boom\n\tpanic at main/main.neva:2:12 (Panic)\n\tmain at main/main.neva:1:10 (Main)\n",
+		string(out),
+	)
+
+	require.Equal(t, 1, cmd.ProcessState.ExitCode())
+}
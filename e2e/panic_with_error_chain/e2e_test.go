@@ -0,0 +1,26 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err)
+
+	require.Equal(
+		t,
+		"panic: cannot save user: connection refused\n"+
+			"\tsaver/panic at main/main.neva:14:12 (Panic)\n"+
+			"\tsaver at main/main.neva:7:12 (Saver)\n"+
+			"\tmain at main/main.neva:6:10 (Main)\n",
+		string(out),
+	)
+
+	require.Equal(t, 1, cmd.ProcessState.ExitCode())
+}
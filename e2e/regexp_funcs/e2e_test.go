@@ -0,0 +1,27 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"true\n"+
+			`["2024","2025"]`+"\n"+
+			"from 01/2024 to 12/2025\n"+
+			`{"month": "01", "year": "2024"}`+"\n"+
+			`[["2024-01","2024","01"],["2025-12","2025","12"]]`+"\n"+
+			"error parsing regexp: missing closing ): `(`\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Program prints messages from 4 concurrent senders and stops after the last one,
+// so replay must reproduce the order in which println received them during recorded run.
+func Test(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+
+	cmd := exec.Command("neva", "run", "--trace", tracePath, "main")
+
+	recorded, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t,
+		[]string{"a", "b", "c", "d"},
+		strings.Fields(string(recorded)),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+	for i := 0; i < 5; i++ {
+		cmd := exec.Command("neva", "replay", tracePath)
+
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			string(recorded),
+			string(out),
+		)
+
+		require.Equal(t, 0, cmd.ProcessState.ExitCode())
+	}
+}
+
+// Trace where println received message from the sender that doesn't exist can't be followed.
+func TestDivergence(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+
+	cmd := exec.Command("neva", "run", "--trace", tracePath, "main")
+	require.NoError(t, cmd.Run())
+
+	bb, err := os.ReadFile(tracePath)
+	require.NoError(t, err)
+
+	lines := strings.Split(string(bb), "\n")
+	replaced := false
+	for i, line := range lines {
+		if strings.Contains(line, `"event":"received"`) &&
+			strings.Contains(line, `"receivers":["println/in:data[0]"]`) {
+			start := strings.Index(line, `"sender":"`) + len(`"sender":"`)
+			end := start + strings.Index(line[start:], `"`)
+			lines[i] = line[:start] + "nowhere/out:data[0]" + line[end:]
+			replaced = true
+			break
+		}
+	}
+	require.True(t, replaced)
+	require.NoError(t, os.WriteFile(tracePath, []byte(strings.Join(lines, "\n")), 0644))
+
+	cmd = exec.Command("neva", "replay", "--timeout", "100ms", tracePath)
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	require.Contains(t, string(out), "replay diverged from the trace")
+	require.Equal(t, 1, cmd.ProcessState.ExitCode())
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Debugger and the program share stdin: debugger only reads commands while program is paused.
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "--debugger", "main")
+
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	out := bufio.NewReader(r)
+
+	require.NoError(t, cmd.Start())
+	require.NoError(t, w.Close()) // only program writes there now
+
+	// debugger stops before the first message
+	waitFor(t, out, "(neva) ")
+
+	// scanln reads the line that goes right after the command that resumed the program
+	_, err = io.WriteString(stdin, "b println/in:data[0]\nc\nhello\n")
+	require.NoError(t, err)
+
+	waitFor(t, out, "stopped (breakpoint)")
+	waitFor(t, out, "msg: hello\n(neva) ")
+
+	_, err = io.WriteString(stdin, "set \"bye\"\nq\n")
+	require.NoError(t, err)
+
+	waitFor(t, out, "msg: bye\n(neva) ")
+
+	require.NoError(t, cmd.Wait())
+
+	rest, err := io.ReadAll(out)
+	require.NoError(t, err)
+	require.Equal(t, "bye\n", string(rest))
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
+
+// waitFor reads output until it ends with s.
+func waitFor(t *testing.T, out *bufio.Reader, s string) {
+	t.Helper()
+
+	var read strings.Builder
+	for !strings.HasSuffix(read.String(), s) {
+		b, err := out.ReadByte()
+		require.NoError(t, err, read.String())
+		read.WriteByte(b)
+	}
+}
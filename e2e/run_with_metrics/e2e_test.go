@@ -0,0 +1,30 @@
+package test
+
+import (
+	"bytes"
+	"os/exec"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Interval is long enough to only get the summary that is printed when program ends.
+func Test(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("neva", "run", "--metrics-interval", "1h", "main")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	require.NoError(t, cmd.Run())
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+	require.Equal(t, "Hello, World!\n", stdout.String())
+
+	summary := stderr.String()
+	require.Regexp(t, `^PORT\s+SENT\s+RECEIVED\s+QUEUE\s+QUEUE MAX\s+BUF\s+AVG WAIT\s+MAX WAIT\n`, summary)
+	require.Regexp(t, regexp.MustCompile(`(?m)^in:start\[0\]\s+1\s+0\s`), summary)
+	require.Regexp(t, regexp.MustCompile(`(?m)^println/in:data\[0\]\s+0\s+1\s`), summary)
+	require.Regexp(t, regexp.MustCompile(`(?m)^out:stop\[0\]\s+0\s+1\s`), summary)
+}
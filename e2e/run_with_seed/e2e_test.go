@@ -0,0 +1,37 @@
+package test
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Program prints messages from 4 concurrent senders and stops after 'd'.
+// Seeded ordering is best-effort, so only the outcome is checked, not the order.
+func Test(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		cmd := exec.Command("neva", "run", "--seed", "42", "main")
+
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err)
+		require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+		lines := strings.Fields(string(out))
+		require.Contains(t, lines, "d")
+		for _, line := range lines {
+			require.Contains(t, []string{"a", "b", "c", "d"}, line)
+		}
+	}
+}
+
+// Without --seed random seed is printed so the same order can be tried again.
+func TestGeneratedSeed(t *testing.T) {
+	cmd := exec.Command("neva", "run", "--seeded", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^--seed -?\d+\n`), string(out))
+}
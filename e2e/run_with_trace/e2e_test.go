@@ -0,0 +1,92 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+
+	cmd := exec.Command("neva", "run", "--trace", tracePath, "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"Hello, World!\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+
+	f, err := os.Open(tracePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	require.True(t, scanner.Scan())
+	var header struct {
+		Program string `json:"program"`
+	}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &header))
+	require.Equal(t, "main", header.Program)
+
+	type record struct {
+		Seq       uint64   `json:"seq"`
+		Time      int64    `json:"ts"`
+		Event     string   `json:"event"`
+		Sender    string   `json:"sender"`
+		Receivers []string `json:"receivers"`
+		Msg       any      `json:"msg"`
+		Source    string   `json:"source"`
+	}
+
+	records := []record{}
+	for scanner.Scan() {
+		var r record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, scanner.Err())
+
+	for i, r := range records {
+		require.Equal(t, uint64(i+1), r.Seq)
+		if i > 0 {
+			require.GreaterOrEqual(t, r.Time, records[i-1].Time)
+		}
+	}
+
+	// greeting sends its message again and again until the program stops,
+	// how many times depends on the scheduler, so only the first one is counted
+	var (
+		counted   []record
+		greetings int
+	)
+	for _, r := range records {
+		if r.Sender == "greeting/out:msg[0]" {
+			greetings++
+			if greetings > 3 {
+				continue
+			}
+		}
+		counted = append(counted, r)
+	}
+
+	// 4 connections, every message is sent, pending and then received
+	require.Len(t, counted, 12)
+
+	last := counted[len(counted)-1]
+	require.Equal(t, "received", last.Event)
+	require.Equal(t, "println/out:sig[0]", last.Sender)
+	require.Equal(t, []string{"out:stop[0]"}, last.Receivers)
+	require.Equal(t, "Hello, World!", last.Msg)
+	require.Equal(t, "main/main.neva:7:2", last.Source) // println node
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"[2,4,6,8,10,12]\n"+
+			"[5,6]\n"+
+			"121\n"+
+			"[1,2]\n"+
+			"[5,6]\n"+
+			`[{"left":1,"right":"a"},{"left":2,"right":"b"},{"left":3,"right":"c"}]`+"\n"+
+			"[5,1,6,2,1,2]\n"+
+			`[{"data":"a","idx":0},{"data":"b","idx":1},{"data":"c","idx":2}]`+"\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
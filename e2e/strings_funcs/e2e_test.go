@@ -0,0 +1,30 @@
+package test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test(t *testing.T) {
+	cmd := exec.Command("neva", "run", "main")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"12 12 true true false 7\n"+
+			"Héllo, neva\n"+
+			"HÉLLO, WORLD\n"+
+			"héllo, world\n"+
+			"éllo, Worl\n"+
+			"llo, World\n"+
+			"Héllo, \n"+
+			`["Héllo,","World"]`+"\n"+
+			"ababab\n",
+		string(out),
+	)
+
+	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
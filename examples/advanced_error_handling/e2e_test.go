@@ -18,13 +18,15 @@ func Test(t *testing.T) {
 
 	cmd := exec.Command("neva", "run", "advanced_error_handling")
 	out, err := cmd.CombinedOutput()
-	require.NoError(t, err)
+	require.Error(t, err)
 	require.Equal(
 		t,
-		`panic: {"text": "Get \"definitely%20not%20a%20valid%20URL\":  unsupported protocol scheme \"\""}
+		`panic: Get "definitely%20not%20a%20valid%20URL": unsupported protocol scheme ""
+	panic at advanced_error_handling/main.neva:4:26 (Panic)
+	main at advanced_error_handling/main.neva:3:10 (Main)
 `,
 		string(out),
 	)
 
-	require.Equal(t, 0, cmd.ProcessState.ExitCode())
+	require.Equal(t, 1, cmd.ProcessState.ExitCode())
 }
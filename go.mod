@@ -7,6 +7,7 @@ require (
 	github.com/antlr4-go/antlr/v4 v4.13.0
 	github.com/go-git/go-git/v5 v5.10.0
 	github.com/golang/mock v1.6.0
+	github.com/google/uuid v1.3.0
 	github.com/stretchr/testify v1.8.2
 	github.com/tliron/commonlog v0.2.10
 	github.com/tliron/glsp v0.2.0
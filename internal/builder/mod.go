@@ -26,12 +26,51 @@ func (p Builder) LoadModuleByPath(
 		return compiler.RawModule{}, "", fmt.Errorf("walk: %w", err)
 	}
 
+	var native map[string][]byte
+	if manifest.Native.Path != "" {
+		native, err = retrieveNativeFuncs(filepath.Join(modRootPath, manifest.Native.Path))
+		if err != nil {
+			return compiler.RawModule{}, "", fmt.Errorf("native funcs: %w", err)
+		}
+	}
+
 	return compiler.RawModule{
 		Manifest: manifest,
 		Packages: pkgs,
+		Native:   native,
 	}, modRootPath, nil
 }
 
+// retrieveNativeFuncs reads Go files (except tests) of the package with native funcs.
+// Package must be a single directory, subdirectories are ignored.
+func retrieveNativeFuncs(dirPath string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		bb, err := os.ReadFile(filepath.Join(dirPath, name))
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = bb
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no go files in %v", dirPath)
+	}
+
+	return files, nil
+}
+
 // retrieveSourceCode recursively walks the given tree and fills given pkgs with neva files
 func retrieveSourceCode(rootPath string, pkgs map[string]compiler.RawPackage) error {
 	fsys := os.DirFS(rootPath)
@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	cli "github.com/urfave/cli/v2"
 
 	"github.com/nevalang/neva/internal/builder"
 	"github.com/nevalang/neva/internal/compiler"
 	"github.com/nevalang/neva/internal/interpreter"
+	"github.com/nevalang/neva/internal/runtime"
 	"github.com/nevalang/neva/pkg"
 )
 
@@ -27,8 +31,20 @@ func NewApp( //nolint:funlen
 	dotc compiler.Compiler,
 ) *cli.App {
 	var (
-		target string
-		debug  bool
+		target   string
+		debug    bool
+		debugger bool
+		dapAddr  string
+		trace    string
+		traceFmt string
+		timeout  time.Duration
+		sched    scheduling
+
+		metricsAddr     string
+		metricsInterval time.Duration
+
+		deadlockTimeout time.Duration
+		drainTimeout    time.Duration
 	)
 
 	return &cli.App{
@@ -103,23 +119,154 @@ func NewApp( //nolint:funlen
 						Usage:       "Show message events in stdout",
 						Destination: &debug,
 					},
+					&cli.BoolFlag{
+						Name:        "debugger",
+						Usage:       "Pause at the first message event and control execution from stdin",
+						Destination: &debugger,
+					},
+					&cli.StringFlag{
+						Name:        "dap",
+						Usage:       "Wait for Debug Adapter Protocol client on the given address (e.g. localhost:4711)",
+						Destination: &dapAddr,
+					},
+					&cli.StringFlag{
+						Name:        "trace",
+						Usage:       "Record every message event to the given file",
+						Destination: &trace,
+					},
+					&cli.StringFlag{
+						Name:        "trace-format",
+						Usage:       "Format of the trace file: jsonl or chrome",
+						Value:       string(interpreter.TraceFormatJSONL),
+						Destination: &traceFmt,
+					},
+					&cli.BoolFlag{
+						Name:        "seeded",
+						Usage:       "Deliver messages one by one choosing between ready ones in the order defined by seed (best-effort, funcs are not controlled so runs are not reproducible)",
+						Destination: &sched.seeded,
+					},
+					&cli.Int64Flag{
+						Name:        "seed",
+						Usage:       "Seed for best-effort seeded ordering, random by default (implies --seeded)",
+						Destination: &sched.seed,
+					},
+					&cli.StringFlag{
+						Name:        "metrics-addr",
+						Usage:       "Serve runtime metrics in Prometheus format on the given address (e.g. localhost:9090)",
+						Destination: &metricsAddr,
+					},
+					&cli.DurationFlag{
+						Name:        "metrics-interval",
+						Usage:       "Print runtime metrics summary to stderr with the given interval and when program ends",
+						Destination: &metricsInterval,
+					},
+					&cli.DurationFlag{
+						Name:        "deadlock-timeout",
+						Usage:       "Stop the program with a dump of pending messages if nothing happens for the given time",
+						Destination: &deadlockTimeout,
+					},
+					&cli.DurationFlag{
+						Name:        "drain-timeout",
+						Usage:       "On SIGINT or SIGTERM give the program this much time to handle os.Signal and stop by itself",
+						Value:       runtime.DefaultShutdownPolicy().DrainTimeout,
+						Destination: &drainTimeout,
+					},
 				},
-				Action: func(cCtx *cli.Context) error {
+				Action: func(cCtx *cli.Context) (err error) {
 					dirFromArg, err := getMainPkgFromArgs(cCtx)
 					if err != nil {
 						return err
 					}
-					intr := interpreter.New(bldr, goc, debug)
+					if cCtx.IsSet("seed") {
+						sched.seeded = true
+					} else if sched.seeded {
+						// like go test -shuffle, print generated seed so the same order can be tried again
+						sched.seed = time.Now().UnixNano()
+						fmt.Fprintf(os.Stderr, "--seed %d\n", sched.seed)
+					}
+					listeners := runtime.ListenerChain{}
+					if debug {
+						listeners = append(listeners, interpreter.DebugEventListener{})
+					}
+					if trace != "" {
+						tracer, closeTrace, err := createTracer(trace, traceFmt, dirFromArg)
+						if err != nil {
+							return err
+						}
+						defer func() {
+							err = errors.Join(err, closeTrace())
+						}()
+						listeners = append(listeners, tracer)
+					}
+					watchers := []runtime.Watcher{}
+					if metricsAddr != "" || metricsInterval > 0 {
+						metrics, stopMetrics, err := startMetrics(metricsAddr, metricsInterval)
+						if err != nil {
+							return err
+						}
+						defer stopMetrics()
+						listeners = append(listeners, metrics)
+						watchers = append(watchers, metrics)
+					}
+					if deadlockTimeout > 0 {
+						if debugger || dapAddr != "" {
+							return errors.New("--deadlock-timeout can't be used with debugger: paused program looks stuck")
+						}
+						detector := runtime.NewDeadlockDetector(deadlockTimeout)
+						listeners = append(listeners, detector)
+						watchers = append(watchers, detector)
+					}
+					shutdown := runtime.DefaultShutdownPolicy()
+					shutdown.DrainTimeout = drainTimeout
+					// everything after the package path belongs to the program
+					ctx := runtime.WithArgs(context.Background(), cCtx.Args().Tail())
+					switch {
+					case dapAddr != "":
+						return runWithDAP(ctx, bldr, goc, workdir, dirFromArg, dapAddr, listeners, sched, shutdown, watchers)
+					case debugger:
+						return runWithREPL(ctx, bldr, goc, workdir, dirFromArg, listeners, sched, shutdown, watchers)
+					}
+					var intr interpreter.Interpreter
+					switch {
+					case len(listeners) > 0:
+						intr = interpreter.New(bldr, goc, sched.connector(listeners), shutdown, watchers...)
+					case sched.seeded: // seed is forwarded to programs with native funcs
+						intr = interpreter.NewSeeded(bldr, goc, sched.seed, shutdown, watchers...)
+					default: // default connector lets programs with native funcs run
+						intr = interpreter.New(bldr, goc, nil, shutdown, watchers...)
+					}
 					if err := intr.Interpret(
-						context.Background(),
+						ctx,
 						workdir,
 						dirFromArg,
 					); err != nil {
-						return err
+						return exitOnRuntimeError(err)
 					}
 					return nil
 				},
 			},
+			{
+				Name:      "replay",
+				Usage:     "Run neva program forcing messages to be received in the order recorded by 'run --trace'",
+				Args:      true,
+				ArgsUsage: "Provide path to the jsonl trace and optionally path to the executable package",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "debug",
+						Usage:       "Show message events in stdout",
+						Destination: &debug,
+					},
+					&cli.DurationFlag{
+						Name:        "timeout",
+						Usage:       "How long message can wait for its turn before replay gives up on the order",
+						Value:       10 * time.Second,
+						Destination: &timeout,
+					},
+				},
+				Action: func(cCtx *cli.Context) error {
+					return replay(bldr, goc, workdir, cCtx.Args().Get(0), cCtx.Args().Get(1), timeout, debug)
+				},
+			},
 			{
 				Name:  "build",
 				Usage: "Build executable binary from neva program source code",
@@ -174,6 +321,232 @@ func NewApp( //nolint:funlen
 	}
 }
 
+// exitOnRuntimeError makes CLI exit with non-zero code if program has failed at runtime
+// or has finished with non-zero exit code.
+// Runtime errors point to nodes by themselves so they're returned without package location.
+// Compiler errors are returned as is.
+func exitOnRuntimeError(err error) error {
+	var (
+		exitErr      *runtime.ExitError
+		codeErr      *runtime.InvalidExitCodeError
+		panicErr     *runtime.PanicError
+		deadlockErr  *runtime.DeadlockError
+		interruptErr *runtime.InterruptError
+	)
+	switch {
+	case errors.As(err, &exitErr):
+		return cli.Exit("", exitErr.Code)
+	case errors.As(err, &codeErr):
+		return cli.Exit(codeErr, 1)
+	case errors.As(err, &panicErr):
+		return cli.Exit(panicErr, 1)
+	case errors.As(err, &deadlockErr):
+		return cli.Exit(deadlockErr, 1)
+	case errors.As(err, &interruptErr):
+		return cli.Exit(interruptErr, interruptErr.ExitCode())
+	}
+	return err
+}
+
+func runWithREPL(
+	ctx context.Context,
+	bldr builder.Builder,
+	goc compiler.Compiler,
+	workdir string,
+	mainPkg string,
+	listeners runtime.ListenerChain,
+	sched scheduling,
+	shutdown runtime.ShutdownPolicy,
+	watchers []runtime.Watcher,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dbg := interpreter.NewDebugger()
+	dbg.Pause() // let user set breakpoints before anything happens
+	go interpreter.RunREPL(ctx, dbg, os.Stdin, os.Stdout)
+
+	// debugger goes first so other listeners see rewritten messages
+	listeners = append(runtime.ListenerChain{dbg}, listeners...)
+
+	if err := interpreter.New(bldr, goc, sched.connector(listeners), shutdown, watchers...).Interpret(ctx, workdir, mainPkg); err != nil {
+		return exitOnRuntimeError(err)
+	}
+
+	return nil
+}
+
+func runWithDAP(
+	ctx context.Context,
+	bldr builder.Builder,
+	goc compiler.Compiler,
+	workdir string,
+	mainPkg string,
+	addr string,
+	listeners runtime.ListenerChain,
+	sched scheduling,
+	shutdown runtime.ShutdownPolicy,
+	watchers []runtime.Watcher,
+) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	fmt.Printf("waiting for debug adapter client on %v\n", lis.Addr())
+
+	conn, err := lis.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dbg := interpreter.NewDebugger()
+	server := interpreter.NewDAPServer(dbg)
+
+	go func() {
+		if err := server.Serve(ctx, conn); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+
+	<-server.Configured()
+	defer server.Terminate()
+
+	listeners = append(runtime.ListenerChain{dbg}, listeners...)
+
+	if err := interpreter.New(bldr, goc, sched.connector(listeners), shutdown, watchers...).Interpret(ctx, workdir, mainPkg); err != nil {
+		return exitOnRuntimeError(err)
+	}
+
+	return nil
+}
+
+func replay(
+	bldr builder.Builder,
+	goc compiler.Compiler,
+	workdir string,
+	tracePath string,
+	mainPkg string,
+	timeout time.Duration,
+	debug bool,
+) error {
+	if tracePath == "" {
+		return errors.New("Provide path to the trace file")
+	}
+
+	f, err := os.Open(tracePath)
+	if err != nil {
+		return err
+	}
+	header, records, err := interpreter.ReadTrace(f)
+	if err := errors.Join(err, f.Close()); err != nil {
+		return fmt.Errorf("read trace: %w", err)
+	}
+
+	if mainPkg == "" {
+		mainPkg = header.Program
+	}
+
+	replayer, err := interpreter.NewReplayer(records, timeout)
+	if err != nil {
+		return err
+	}
+
+	listeners := runtime.ListenerChain{replayer}
+	if debug {
+		listeners = append(listeners, interpreter.DebugEventListener{})
+	}
+
+	if err := interpreter.New(bldr, goc, runtime.NewConnector(listeners), runtime.DefaultShutdownPolicy()).Interpret(
+		context.Background(),
+		workdir,
+		mainPkg,
+	); err != nil {
+		return exitOnRuntimeError(err)
+	}
+
+	if divergences := replayer.Divergences(); len(divergences) > 0 {
+		return cli.Exit(
+			fmt.Sprintf(
+				"replay diverged from the trace %d time(s):\n%s",
+				len(divergences),
+				strings.Join(divergences, "\n"),
+			),
+			1,
+		)
+	}
+
+	return nil
+}
+
+// scheduling defines how runtime delivers messages.
+type scheduling struct {
+	seeded bool
+	seed   int64
+}
+
+func (s scheduling) connector(listener runtime.EventListener) runtime.Connector {
+	if s.seeded {
+		return runtime.NewSeededConnector(listener, s.seed)
+	}
+	return runtime.NewConnector(listener)
+}
+
+// startMetrics creates metrics and starts serving and reporting them.
+// Returned function stops both and prints final summary if reporting is enabled.
+func startMetrics(addr string, interval time.Duration) (*interpreter.Metrics, func(), error) {
+	metrics := interpreter.NewMetrics()
+
+	var srv *http.Server
+	if addr != "" {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics)
+		srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go srv.Serve(lis) //nolint:errcheck // always returns ErrServerClosed after Close
+		fmt.Fprintf(os.Stderr, "serving metrics on http://%v/metrics\n", lis.Addr())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if interval > 0 {
+		go metrics.Report(ctx, os.Stderr, interval)
+	}
+
+	return metrics, func() {
+		cancel()
+		if srv != nil {
+			srv.Close()
+		}
+		if interval > 0 {
+			metrics.WriteSummary(os.Stderr) //nolint:errcheck
+		}
+	}, nil
+}
+
+func createTracer(path, format, mainPkg string) (*interpreter.Tracer, func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tracer, err := interpreter.NewTracer(f, interpreter.TraceFormat(format), mainPkg)
+	if err != nil {
+		return nil, nil, errors.Join(err, f.Close())
+	}
+
+	return tracer, func() error {
+		return errors.Join(tracer.Close(), f.Close())
+	}, nil
+}
+
 func getMainPkgFromArgs(cCtx *cli.Context) (string, error) {
 	firstArg := cCtx.Args().First()
 	dirFromArg := strings.TrimSuffix(firstArg, "/main.neva")
@@ -23,11 +23,14 @@ var (
 	ErrUnknownEntityKind    = errors.New("Entity kind can only be either component, interface, type of constant")
 	ErrCompilerVersion      = errors.New("Incompatible compiler version")
 	ErrDepModWithoutVersion = errors.New("Every dependency module must have version")
+	ErrNativeFuncsNoPath    = errors.New("Manifest declares native funcs but not the path to their Go package")
+	ErrNativeFuncConflict   = errors.New("Native func ref is already used by std or another module")
 )
 
 type Analyzer struct {
 	compilerVersion string
 	resolver        ts.Resolver
+	funcs           compiler.FuncPorts // to check #extern references
 }
 
 func (a Analyzer) AnalyzeExecutableBuild(build src.Build, mainPkgName string) (src.Build, *compiler.Error) {
@@ -71,6 +74,10 @@ func (a Analyzer) AnalyzeExecutableBuild(build src.Build, mainPkgName string) (s
 }
 
 func (a Analyzer) AnalyzeBuild(build src.Build) (src.Build, *compiler.Error) {
+	if err := a.analyzeNativeFuncs(build); err != nil {
+		return src.Build{}, err
+	}
+
 	analyzedMods := make(map[src.ModuleRef]src.Module, len(build.Modules))
 
 	for modRef, mod := range build.Modules {
@@ -86,6 +93,7 @@ func (a Analyzer) AnalyzeBuild(build src.Build) (src.Build, *compiler.Error) {
 		analyzedMods[modRef] = src.Module{
 			Manifest: mod.Manifest,
 			Packages: analyzedPkgs,
+			Native:   mod.Native,
 		}
 	}
 
@@ -95,6 +103,39 @@ func (a Analyzer) AnalyzeBuild(build src.Build) (src.Build, *compiler.Error) {
 	}, nil
 }
 
+// analyzeNativeFuncs makes sure native funcs can be registered in one runtime together with std ones.
+func (a Analyzer) analyzeNativeFuncs(build src.Build) *compiler.Error {
+	declaredBy := map[string]src.ModuleRef{}
+
+	for modRef, mod := range build.Modules {
+		native := mod.Manifest.Native
+		if len(native.Funcs) > 0 && native.Path == "" {
+			return &compiler.Error{
+				Err:      ErrNativeFuncsNoPath,
+				Location: &src.Location{ModRef: modRef},
+			}
+		}
+
+		for _, ref := range native.Funcs {
+			if _, _, ok := a.funcs.Ports(ref); ok {
+				return &compiler.Error{
+					Err:      fmt.Errorf("%w: %v", ErrNativeFuncConflict, ref),
+					Location: &src.Location{ModRef: modRef},
+				}
+			}
+			if other, ok := declaredBy[ref]; ok {
+				return &compiler.Error{
+					Err:      fmt.Errorf("%w: %v is also declared by %v", ErrNativeFuncConflict, ref, other),
+					Location: &src.Location{ModRef: modRef},
+				}
+			}
+			declaredBy[ref] = modRef
+		}
+	}
+
+	return nil
+}
+
 func (a Analyzer) analyzeModule(modRef src.ModuleRef, build src.Build) (map[string]src.Package, *compiler.Error) {
 	if modRef != build.EntryModRef && modRef.Version == "" {
 		return nil, &compiler.Error{
@@ -243,9 +284,10 @@ func (a Analyzer) analyzeEntity(entity src.Entity, scope src.Scope) (src.Entity,
 	return resolvedEntity, nil
 }
 
-func MustNew(version string, resolver ts.Resolver) Analyzer {
+func MustNew(version string, resolver ts.Resolver, funcs compiler.FuncPorts) Analyzer {
 	return Analyzer{
 		compilerVersion: version,
 		resolver:        resolver,
+		funcs:           funcs,
 	}
 }
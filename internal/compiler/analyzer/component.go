@@ -2,6 +2,8 @@ package analyzer
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/nevalang/neva/internal/compiler"
@@ -29,10 +31,13 @@ var (
 	ErrNormComponentWithoutNet    = errors.New("Component must have network except it uses #extern directive")
 	ErrNormNodeBind               = errors.New("Node can't use #bind if it isn't instantiated with the component that use #extern")
 	ErrInterfaceNodeBindDirective = errors.New("Interface node cannot use #bind directive")
+	ErrTypeInfoNodeBind           = errors.New("Node can't use #bind if it's instantiated with the component that use #typeinfo")
 	ErrExternNoArgs               = errors.New("Component that use #extern directive must provide at least one argument")
 	ErrBindDirectiveArgs          = errors.New("Node with #bind directive must provide exactly one argument")
 	ErrExternOverloadingArg       = errors.New("Component that use #extern with more than one argument must provide arguments in a form of <type, component_ref> pairs")
 	ErrExternOverloadingNodeArgs  = errors.New("Node instantiated with component with #extern with > 1 argument, must have exactly one type-argument for overloading")
+	ErrExternNotImplemented       = errors.New("Component refers to runtime function that does not exist")
+	ErrExternPortsMismatch        = errors.New("Component interface does not match ports of runtime function")
 )
 
 // Maybe start here
@@ -86,6 +91,14 @@ func (a Analyzer) analyzeComponent( //nolint:funlen
 				Meta:     &component.Meta,
 			}
 		}
+		native := scope.Build.Modules[scope.Location.ModRef].Manifest.Native.Funcs
+		if err := a.analyzeRuntimeFuncRefs(component.Interface, runtimeFuncArgs, native); err != nil {
+			return src.Component{}, &compiler.Error{
+				Err:      err,
+				Location: &scope.Location,
+				Meta:     &component.Meta,
+			}
+		}
 		return component, nil
 	}
 
@@ -128,5 +141,45 @@ func (a Analyzer) analyzeComponent( //nolint:funlen
 		Interface: resolvedInterface,
 		Nodes:     resolvedNodes,
 		Net:       analyzedNet,
+		Meta:      component.Meta,
 	}, nil
 }
+
+// analyzeRuntimeFuncRefs checks that runtime or module's native funcs implement every func referenced by #extern
+// and that component's ports are exactly the ones that func reads. Ports of native funcs are only known at runtime.
+func (a Analyzer) analyzeRuntimeFuncRefs(iface src.Interface, runtimeFuncArgs []string, native []string) error {
+	for _, arg := range runtimeFuncArgs {
+		parts := strings.Split(arg, " ") // overloaded externs look like "int int_add"
+		ref := parts[len(parts)-1]
+
+		if slices.Contains(native, ref) {
+			continue
+		}
+
+		in, out, ok := a.funcs.Ports(ref)
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrExternNotImplemented, ref)
+		}
+
+		if !samePortNames(iface.IO.In, in) || !samePortNames(iface.IO.Out, out) {
+			return fmt.Errorf(
+				"%w: %v reads inports %v and outports %v",
+				ErrExternPortsMismatch, ref, in, out,
+			)
+		}
+	}
+
+	return nil
+}
+
+func samePortNames(ports map[string]src.Port, names []string) bool {
+	if len(ports) != len(names) {
+		return false
+	}
+	for _, name := range names {
+		if _, ok := ports[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -262,6 +262,15 @@ func (a Analyzer) getNodeInterface( //nolint:funlen
 		}
 	}
 
+	// runtime func receives description of the type argument instead of bound message
+	if _, hasTypeInfoDirective := entity.Component.Directives[compiler.TypeInfoDirective]; hasConfigMsg && hasTypeInfoDirective {
+		return src.Interface{}, &compiler.Error{
+			Err:      ErrTypeInfoNodeBind,
+			Location: &location,
+			Meta:     entity.Meta(),
+		}
+	}
+
 	if len(externArgs) > 1 && len(node.TypeArgs) != 1 {
 		return src.Interface{}, &compiler.Error{
 			Err:      ErrExternOverloadingNodeArgs,
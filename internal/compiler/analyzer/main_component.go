@@ -17,6 +17,7 @@ var (
 	ErrMainComponentWithoutExitOutport = errors.New("Main component must have 'exit' outport")
 	ErrMainPortIsArray                 = errors.New("Main component cannot have array ports")
 	ErrMainComponentPortTypeNotAny     = errors.New("Main component's ports must be of type any")
+	ErrMainComponentStopType           = errors.New("Main component's 'stop' outport must be of type any or int")
 	ErrMainComponentNodeNotComponent   = errors.New("Main component's nodes must only refer to components")
 )
 
@@ -66,7 +67,7 @@ func (a Analyzer) analyzeMainComponentIO(io src.IO) *compiler.Error {
 	if !ok {
 		return &compiler.Error{Err: ErrMainComponentWithoutExitOutport}
 	}
-	if err := a.analyzeMainComponentPort(exitOutport); err != nil {
+	if err := a.analyzeMainComponentStopPort(exitOutport); err != nil {
 		return &compiler.Error{
 			Err:  err,
 			Meta: &exitOutport.Meta,
@@ -86,6 +87,17 @@ func (a Analyzer) analyzeMainComponentPort(port src.Port) error {
 	return nil
 }
 
+// analyzeMainComponentStopPort allows int stop outport, its message becomes the exit code of the program.
+func (a Analyzer) analyzeMainComponentStopPort(port src.Port) error {
+	if port.IsArray {
+		return ErrMainPortIsArray
+	}
+	if !(src.Scope{}).IsTopType(port.TypeExpr) && !(src.Scope{}).IsIntType(port.TypeExpr) {
+		return ErrMainComponentStopType
+	}
+	return nil
+}
+
 func (Analyzer) analyzeMainComponentNodes(
 	nodes map[string]src.Node,
 	scope src.Scope,
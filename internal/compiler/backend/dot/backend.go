@@ -20,7 +20,7 @@ func (b Backend) Emit(dst string, prog *ir.Program) error {
 		return err
 	}
 	defer f.Close()
-	var cb ClusterBuilder
+	cb := ClusterBuilder{SourceMap: prog.SourceMap}
 	for _, e := range prog.Connections {
 		for _, r := range e.ReceiverSides {
 			cb.InsertEdge(e.SenderSide, r.PortAddr)
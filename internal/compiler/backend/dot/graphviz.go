@@ -110,7 +110,8 @@ func (c *Cluster) getOrCreateClusterNodeRec(b *ClusterBuilder, path, prefix, rem
 			return n
 		}
 		n = &Node{
-			Name: path,
+			Name:  path,
+			Extra: b.nodeExtra(path),
 		}
 		c.Nodes[before] = n
 		return n
@@ -141,8 +142,9 @@ func (c *Cluster) Label() string {
 }
 
 type ClusterBuilder struct {
-	Main  *Cluster
-	Edges []Edge
+	Main      *Cluster
+	Edges     []Edge
+	SourceMap map[string]ir.SourceLocation // Optional, used to show where nodes are declared
 
 	nextId int
 	once   sync.Once
@@ -174,6 +176,18 @@ func (b *ClusterBuilder) insertClusterNode(addr ir.PortAddr) {
 	}
 }
 
+// nodeExtra returns escaped component name and location of the node if it's known.
+func (b *ClusterBuilder) nodeExtra(path string) string {
+	loc, ok := b.SourceMap[path]
+	if !ok {
+		return ""
+	}
+	if loc.Line == 0 {
+		return html.EscapeString(fmt.Sprintf("%s %s", loc.Component, loc.File))
+	}
+	return html.EscapeString(fmt.Sprintf("%s %s:%d", loc.Component, loc.File, loc.Line))
+}
+
 func (b *ClusterBuilder) InsertEdge(send, recv ir.PortAddr) {
 	b.insertClusterNode(send)
 	b.insertClusterNode(recv)
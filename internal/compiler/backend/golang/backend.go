@@ -2,18 +2,41 @@ package golang
 
 import (
 	"bytes"
+	"embed"
 	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
 	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/nevalang/neva/internal"
 	"github.com/nevalang/neva/internal/compiler"
 	"github.com/nevalang/neva/internal/runtime/ir"
+	"github.com/nevalang/neva/pkg"
 )
 
 type Backend struct{}
 
+// Module path must match imports in runtime and its public API for native funcs,
+// dependencies must match compiler's go.mod (see backend_test.go).
+const (
+	goMod = `module github.com/nevalang/neva
+
+go 1.21
+
+require golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc
+`
+	goSum = `golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc h1:mCRnTeVUjcrhlRmO0VK8a6k6Rrf6TF9htwo2pJVSjIU=
+golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc/go.mod h1:V1LtkGg67GoY2N1AnLN78QLrzxkLyJw7RJb1gzOOz9w=
+`
+)
+
 var (
+	ErrNativeImport   = errors.New("native package imports module that generated program doesn't require")
 	ErrExecTmpl       = errors.New("execute template")
 	ErrWrongGoVersion = errors.New("wrong Go version")
 	ErrUnknownMsgType = errors.New("unknown msg type")
@@ -37,43 +60,125 @@ func (b Backend) Emit(dst string, prog *ir.Program) error {
 
 	result := map[string][]byte{}
 	result["main.go"] = buf.Bytes()
-	result["go.mod"] = []byte("module github.com/nevalang/neva/internal\n\ngo 1.21") //nolint:lll // must match imports in runtime package
+	result["go.mod"] = []byte(goMod)
+	result["go.sum"] = []byte(goSum)
 
 	if err := putRuntime(result); err != nil {
 		return err
 	}
 
+	if err := putNative(result, prog.Native); err != nil {
+		return err
+	}
+
 	return compiler.SaveFilesToDir(dst, result)
 }
 
+// putRuntime puts runtime and public packages into the module at the same paths they have in compiler's module.
 func putRuntime(files map[string][]byte) error {
-	if err := fs.WalkDir(
-		internal.Efs,
-		"runtime",
-		func(path string, dirEntry fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
+	for _, dir := range []struct {
+		prefix string
+		efs    embed.FS
+	}{
+		{"internal", internal.Efs},
+		{"pkg", pkg.Efs},
+	} {
+		if err := fs.WalkDir(
+			dir.efs,
+			"runtime",
+			func(path string, dirEntry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
 
-			if dirEntry.IsDir() {
+				if dirEntry.IsDir() {
+					return nil
+				}
+
+				bb, err := dir.efs.ReadFile(path)
+				if err != nil {
+					return err
+				}
+
+				files[dir.prefix+"/"+path] = bb
 				return nil
-			}
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-			bb, err := internal.Efs.ReadFile(path)
-			if err != nil {
-				return err
+// putNative puts every native package into its own directory, main.go imports them by index.
+// Generated module only has dependencies of the runtime, so native packages can't import other modules.
+func putNative(files map[string][]byte, native []ir.NativePackage) error {
+	for i, nativePkg := range native {
+		names := make([]string, 0, len(nativePkg.Files))
+		for name := range nativePkg.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			bb := nativePkg.Files[name]
+			if err := checkNativeImports(name, bb); err != nil {
+				return fmt.Errorf("%v: %w", nativePkg.Module, err)
 			}
+			files[fmt.Sprintf("internal/native/%d/%s", i, name)] = bb
+		}
+	}
+	return nil
+}
 
-			files[path] = bb
-			return nil
-		},
-	); err != nil {
+func checkNativeImports(fileName string, bb []byte) error {
+	f, err := parser.ParseFile(token.NewFileSet(), fileName, bb, parser.ImportsOnly)
+	if err != nil {
 		return err
 	}
 
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return err
+		}
+		if isThirdParty(importPath) && !isRequired(importPath) {
+			return fmt.Errorf(
+				"%w: %v: %v (only standard library, github.com/nevalang/neva/pkg/... and %v can be imported)",
+				ErrNativeImport, fileName, importPath, strings.Join(requiredModules(), ", "),
+			)
+		}
+	}
+
 	return nil
 }
 
+func isThirdParty(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return strings.Contains(first, ".") && !strings.HasPrefix(importPath, "github.com/nevalang/neva/")
+}
+
+func isRequired(importPath string) bool {
+	for _, mod := range requiredModules() {
+		if importPath == mod || strings.HasPrefix(importPath, mod+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredModules returns paths of modules that generated go.mod requires.
+func requiredModules() []string {
+	var mods []string
+	for _, line := range strings.Split(goMod, "\n") {
+		if dep, ok := strings.CutPrefix(line, "require "); ok {
+			mods = append(mods, strings.Fields(dep)[0])
+		}
+	}
+	return mods
+}
+
 func NewBackend() Backend {
 	return Backend{}
 }
@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"embed"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nevalang/neva/internal"
+	"github.com/nevalang/neva/pkg"
+)
+
+// Generated module must be built with the same dependencies as compiler's module.
+func Test_goModMatchesRoot(t *testing.T) {
+	rootMod, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootSum, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(goMod, "\n") {
+		dep, ok := strings.CutPrefix(line, "require ")
+		if ok && !strings.Contains(string(rootMod), "\t"+dep+"\n") {
+			t.Errorf("go.mod doesn't require %v", dep)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(goSum), "\n") {
+		if !strings.Contains(string(rootSum), line+"\n") {
+			t.Errorf("go.sum doesn't have %v", line)
+		}
+	}
+
+	// every third-party import of the runtime must be required
+	for _, efs := range []embed.FS{internal.Efs, pkg.Efs} {
+		err := fs.WalkDir(efs, "runtime", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+
+			bb, err := efs.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			f, err := parser.ParseFile(token.NewFileSet(), path, bb, parser.ImportsOnly)
+			if err != nil {
+				return err
+			}
+
+			for _, imp := range f.Imports {
+				importPath, err := strconv.Unquote(imp.Path.Value)
+				if err != nil {
+					return err
+				}
+				if !isThirdParty(importPath) {
+					continue
+				}
+				if !isRequired(importPath) {
+					t.Errorf("%v: import %v is not required by generated go.mod", path, importPath)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -5,22 +5,64 @@ package main
 
 import (
     "context"
+    "errors"
+    "fmt"
+    "os"
+    "strconv"
+    "time"
 
     "github.com/nevalang/neva/internal/runtime"
     "github.com/nevalang/neva/internal/runtime/funcs"
+    {{- range $idx, $pkg := .Native}}
+    _ "github.com/nevalang/neva/internal/native/{{$idx}}" // {{$pkg.Module}}
+    {{- end}}
 )
 
 func main() {
     // runtime
-    connector := runtime.NewDefaultConnector()
+    var listener runtime.EventListener = runtime.EmptyListener{}
+    var watchers []runtime.Watcher
+    if s, ok := os.LookupEnv("NEVA_DEADLOCK_TIMEOUT"); ok { // e.g. "5s", see runtime.DeadlockDetector
+        timeout, err := time.ParseDuration(s)
+        if err != nil {
+            panic(fmt.Errorf("NEVA_DEADLOCK_TIMEOUT: %w", err))
+        }
+        detector := runtime.NewDeadlockDetector(timeout)
+        listener = detector
+        watchers = append(watchers, detector)
+    }
+    var connector runtime.Connector = runtime.NewConnector(listener)
+    seed, seeded := os.LookupEnv("NEVA_SEED") // seeded mode, see runtime.SeededConnector
+    if seeded {
+        n, err := strconv.ParseInt(seed, 10, 64)
+        if err != nil {
+            panic(fmt.Errorf("NEVA_SEED: %w", err))
+        }
+        connector = runtime.NewSeededConnector(listener, n)
+    }
+    shutdown := runtime.DefaultShutdownPolicy()
+    if s, ok := os.LookupEnv("NEVA_DRAIN_TIMEOUT"); ok { // time to stop after SIGINT or SIGTERM, see runtime.ShutdownPolicy
+        timeout, err := time.ParseDuration(s)
+        if err != nil {
+            panic(fmt.Errorf("NEVA_DRAIN_TIMEOUT: %w", err))
+        }
+        shutdown.DrainTimeout = timeout
+    }
 	funcRunner := runtime.MustNewFuncRunner(funcs.CreatorRegistry())
-	runTime := runtime.New(connector, funcRunner)
+	runTime := runtime.New(connector, funcRunner, watchers...)
 
     // ports
     {{- range $idx, $info := .Ports}}
     {{getPortChanName $info.PortAddr}} := make(chan runtime.Msg, {{$info.BufSize}})
     {{- end}}
 
+    // source map
+    sourceMap := runtime.SourceMap{
+        {{- range $path, $loc := .SourceMap}}
+        "{{$path}}": {File: {{printf "%q" $loc.File}}, Line: {{$loc.Line}}, Column: {{$loc.Column}}, Component: {{printf "%q" $loc.Component}}},
+        {{- end}}
+    }
+
 	// program
     prog := runtime.Program{
         Ports: map[runtime.PortAddr]chan runtime.Msg{
@@ -57,6 +99,12 @@ func main() {
                         },
                     {{- end}}
                     },
+                    SenderLocation: sourceMap.Lookup(runtime.PortAddr{Path: "{{.SenderSide.Path}}"}),
+                    ReceiverLocations: []runtime.SourceLocation{
+                    {{- range .ReceiverSides }}
+                        sourceMap.Lookup(runtime.PortAddr{Path: "{{.PortAddr.Path}}"}),
+                    {{- end}}
+                    },
                 },
             },
             {{- end}}
@@ -74,12 +122,33 @@ func main() {
                     },
                 },
                 ConfigMsg: {{getMsg .Msg}},
+                NodePath: runtime.PortAddr{Path: "{{.IO.Path}}"}.NodePath(),
             },
             {{- end}}
         },
+        SourceMap: sourceMap,
+        StopIsExitCode: {{.StopIsExitCode}},
     }
     
-    if err := runTime.Run(context.Background(), prog); err != nil {
-        panic(err)
+    if err := runTime.Run(context.Background(), prog, shutdown); err != nil {
+        var exitErr *runtime.ExitError
+        if errors.As(err, &exitErr) {
+            os.Exit(exitErr.Code)
+        }
+        var codeErr *runtime.InvalidExitCodeError
+        if errors.As(err, &codeErr) {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(1)
+        }
+        var interruptErr *runtime.InterruptError
+        if errors.As(err, &interruptErr) {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(interruptErr.ExitCode())
+        }
+        if seeded {
+            fmt.Fprintf(os.Stderr, "seeded run failed, NEVA_SEED=%v may lead to the same order\n", seed)
+        }
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
     }
 }`
@@ -11,6 +11,7 @@ const (
 	ExternDirective    src.Directive = "extern"
 	BindDirective      src.Directive = "bind"
 	AutoportsDirective src.Directive = "autoports"
+	TypeInfoDirective  src.Directive = "typeinfo"
 )
 
 type (
@@ -30,6 +31,7 @@ type (
 	RawModule struct {
 		Manifest src.ModuleManifest    // Manifest must be parsed by builder before passing into compiler
 		Packages map[string]RawPackage // Packages themselves on the other hand can be parsed by compiler
+		Native   map[string][]byte     // Go files of the package from Manifest.Native, compiler doesn't parse them
 	}
 
 	RawPackage map[string][]byte
@@ -38,6 +40,12 @@ type (
 		AnalyzeExecutableBuild(mod src.Build, mainPkgName string) (src.Build, *Error)
 	}
 
+	// FuncPorts tells analyzer which ports runtime funcs read, so it can check components that refer to them.
+	FuncPorts interface {
+		// Ports returns names of func's inports and outports, ok is false if there's no such func.
+		Ports(ref string) (in, out []string, ok bool)
+	}
+
 	Desugarer interface {
 		Desugar(build src.Build) (src.Build, *Error)
 	}
@@ -57,6 +57,7 @@ func (d Desugarer) handleLiteralSender(
 				Message.
 				TypeExpr,
 		},
+		Meta: conn.Meta, // emitter is declared where the constant is used
 	}
 
 	emitterCounter := virtualEmittersCount.Load()
@@ -119,6 +120,7 @@ func (d Desugarer) handleConstRefSender(
 		},
 		EntityRef: emitterComponentRef,
 		TypeArgs:  []ts.Expr{constTypeExpr},
+		Meta:      conn.Meta,
 	}
 	emitterNodeOutportAddr := src.PortAddr{
 		Node: virtualEmitterName,
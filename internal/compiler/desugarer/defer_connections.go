@@ -78,7 +78,9 @@ func (d Desugarer) handleDeferredConnections( //nolint:funlen
 		counter := virtualBlockersCounter.Load()
 		virtualBlockersCounter.Store(counter + 1)
 		virtualBlockerName := fmt.Sprintf("__lock__%d", counter)
-		virtualNodes[virtualBlockerName] = virtualBlockerNode
+		blockerNode := virtualBlockerNode
+		blockerNode.Meta = desugaredThenConn.Meta // so runtime errors can point to deferred connection
+		virtualNodes[virtualBlockerName] = blockerNode
 
 		// 2) create connection from original sender to blocker:sig
 		receiversForOriginalSender = append(
@@ -107,6 +109,7 @@ func (d Desugarer) handleDeferredConnections( //nolint:funlen
 						},
 					},
 				},
+				Meta: desugaredThenConn.Meta,
 			},
 			// 4) create connection from blocker:data to every receiver in deferred connection
 			src.Connection{
@@ -121,6 +124,7 @@ func (d Desugarer) handleDeferredConnections( //nolint:funlen
 						Receivers: deferredConnection.ReceiverSide.Receivers,
 					},
 				},
+				Meta: desugaredThenConn.Meta,
 			},
 		)
 	}
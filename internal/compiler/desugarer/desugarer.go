@@ -39,6 +39,7 @@ func (d Desugarer) desugarModule(build src.Build, modRef src.ModuleRef) (src.Mod
 	desugaredManifest := src.ModuleManifest{
 		LanguageVersion: mod.Manifest.LanguageVersion,
 		Deps:            make(map[string]src.ModuleRef, len(mod.Manifest.Deps)+1),
+		Native:          mod.Manifest.Native,
 	}
 	maps.Copy(desugaredManifest.Deps, mod.Manifest.Deps)
 	desugaredManifest.Deps["std"] = src.ModuleRef{Path: "std", Version: pkg.Version}
@@ -48,6 +49,7 @@ func (d Desugarer) desugarModule(build src.Build, modRef src.ModuleRef) (src.Mod
 	modsCopy[modRef] = src.Module{
 		Manifest: desugaredManifest,
 		Packages: mod.Packages,
+		Native:   mod.Native,
 	}
 
 	// create new build with patched modeles (current module have patched manifest with std dependency)
@@ -80,6 +82,7 @@ func (d Desugarer) desugarModule(build src.Build, modRef src.ModuleRef) (src.Mod
 	return src.Module{
 		Manifest: desugaredManifest,
 		Packages: desugaredPkgs,
+		Native:   mod.Native,
 	}, nil
 }
 
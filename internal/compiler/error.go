@@ -26,6 +26,11 @@ func (e Error) unwrap() Error {
 	return e
 }
 
+// Unwrap returns the error of the innermost child so errors.Is and errors.As can see it.
+func (e Error) Unwrap() error {
+	return e.unwrap().Err
+}
+
 func (e Error) Error() string {
 	e = e.unwrap()
 
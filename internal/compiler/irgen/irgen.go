@@ -5,6 +5,8 @@ package irgen
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/nevalang/neva/internal/compiler"
 	src "github.com/nevalang/neva/internal/compiler/sourcecode"
@@ -48,6 +50,7 @@ func (g Generator) Generate(build src.Build, mainPkgName string) (*ir.Program, *
 		Ports:       []ir.PortInfo{},
 		Connections: []ir.Connection{},
 		Funcs:       []ir.FuncCall{},
+		SourceMap:   map[string]ir.SourceLocation{},
 	}
 
 	rootNodeCtx := nodeContext{
@@ -74,9 +77,40 @@ func (g Generator) Generate(build src.Build, mainPkgName string) (*ir.Program, *
 		}.Wrap(err)
 	}
 
+	// analyzer makes sure Main exists and its stop outport is either any or int
+	mainEntity, _, err := initialScope.Entity(rootNodeCtx.node.EntityRef)
+	if err != nil {
+		return nil, &compiler.Error{
+			Err:      err,
+			Location: &initialScope.Location,
+		}
+	}
+	stop := mainEntity.Component.Interface.IO.Out["stop"]
+	result.StopIsExitCode = initialScope.IsIntType(stop.TypeExpr)
+
+	result.Native = getNativePackages(build)
+
 	return result, nil
 }
 
+// getNativePackages returns Go packages of modules that ship native funcs in stable order.
+func getNativePackages(build src.Build) []ir.NativePackage {
+	var result []ir.NativePackage
+	for modRef, mod := range build.Modules {
+		if len(mod.Native) == 0 {
+			continue
+		}
+		result = append(result, ir.NativePackage{
+			Module: modRef.String(),
+			Files:  mod.Native,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Module < result[j].Module
+	})
+	return result
+}
+
 func (g Generator) processComponentNode( //nolint:funlen
 	nodeCtx nodeContext,
 	scope src.Scope,
@@ -92,6 +126,8 @@ func (g Generator) processComponentNode( //nolint:funlen
 
 	component := componentEntity.Component
 
+	g.insertSourceLocation(nodeCtx, scope.Location, location, component.Meta, result)
+
 	// for inports we only use parent context because all inports are used
 	inportAddrs := g.insertAndReturnInports(nodeCtx, result)
 	//  for outports we use both parent context and component's interface
@@ -110,7 +146,7 @@ func (g Generator) processComponentNode( //nolint:funlen
 	// ports are already created, so it's time to create func call
 	if runtimeFuncRef != "" {
 		// use prev location, not the location where runtime func was found
-		runtimeFuncMsg, err := getRuntimeFuncMsg(nodeCtx.node, scope)
+		runtimeFuncMsg, err := getRuntimeFuncMsg(component, nodeCtx.node, scope)
 		if err != nil {
 			return &compiler.Error{
 				Err:      err,
@@ -130,6 +166,8 @@ func (g Generator) processComponentNode( //nolint:funlen
 		return nil
 	}
 
+	// injected nodes are declared where this node is, so they are resolved in the same scope
+	parentScope := scope
 	scope = scope.WithLocation(location) // only use new location if that's not builtin
 
 	// We use network as a source of true about how subnodes ports instead subnodes interface definitions.
@@ -163,11 +201,13 @@ func (g Generator) processComponentNode( //nolint:funlen
 			node:       node,
 		}
 
+		subNodeScope := scope
 		if injectedNode, ok := nodeCtx.node.Deps[nodeName]; ok {
 			subNodeCtx.node = injectedNode
+			subNodeScope = parentScope
 		}
 
-		if err := g.processComponentNode(subNodeCtx, scope, result); err != nil {
+		if err := g.processComponentNode(subNodeCtx, subNodeScope, result); err != nil {
 			return &compiler.Error{
 				Err:      fmt.Errorf("%w: node '%v'", err, nodeName),
 				Location: &location,
@@ -179,6 +219,33 @@ func (g Generator) processComponentNode( //nolint:funlen
 	return nil
 }
 
+// insertSourceLocation maps node path to the place where node is declared.
+// Root node isn't declared anywhere so it's mapped to the Main component itself.
+func (Generator) insertSourceLocation(
+	nodeCtx nodeContext,
+	parentLocation src.Location,
+	componentLocation src.Location,
+	componentMeta core.Meta,
+	result *ir.Program,
+) {
+	if len(nodeCtx.path) == 0 {
+		result.SourceMap["main"] = ir.SourceLocation{
+			File:      componentLocation.String(),
+			Line:      componentMeta.Start.Line,
+			Column:    componentMeta.Start.Column,
+			Component: nodeCtx.node.EntityRef.String(),
+		}
+		return
+	}
+
+	result.SourceMap[strings.Join(nodeCtx.path, "/")] = ir.SourceLocation{
+		File:      parentLocation.String(),
+		Line:      nodeCtx.node.Meta.Start.Line,
+		Column:    nodeCtx.node.Meta.Start.Column,
+		Component: nodeCtx.node.EntityRef.String(),
+	}
+}
+
 func New() Generator {
 	return Generator{}
 }
@@ -0,0 +1,157 @@
+package irgen
+
+import (
+	"testing"
+
+	"github.com/nevalang/neva/internal/compiler"
+	src "github.com/nevalang/neva/internal/compiler/sourcecode"
+	"github.com/nevalang/neva/internal/compiler/sourcecode/core"
+	"github.com/nevalang/neva/internal/runtime/ir"
+	"github.com/nevalang/neva/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// Injected nodes are declared in the component that uses the node they are injected into,
+// so they must be resolved there and not in the package of the component that receives them.
+func TestGenerator_Generate_InjectedNodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  src.Node              // Node injected into std Map
+		userEnts map[string]src.Entity // Components of main package besides Main
+		wantPath string                // Path of Inc func call
+	}{
+		{
+			name:     "user component injected into std component",
+			handler:  src.Node{EntityRef: core.EntityRef{Name: "Inc"}},
+			userEnts: map[string]src.Entity{"Inc": externComponent("inc")},
+			wantPath: "m/handler",
+		},
+		{
+			name: "user component with its own dependency injected into std component",
+			handler: src.Node{
+				EntityRef: core.EntityRef{Name: "Apply"},
+				Deps: map[string]src.Node{
+					"f": {EntityRef: core.EntityRef{Name: "Inc"}},
+				},
+			},
+			userEnts: map[string]src.Entity{
+				"Inc":   externComponent("inc"),
+				"Apply": proxyComponent("f"),
+			},
+			wantPath: "m/handler/f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainEntities := map[string]src.Entity{
+				"Main": {
+					Kind: src.ComponentEntity,
+					Component: src.Component{
+						Interface: componentInterface("start", "stop"),
+						Nodes: map[string]src.Node{
+							"m": {
+								EntityRef: core.EntityRef{Name: "Map"},
+								Deps:      map[string]src.Node{"handler": tt.handler},
+							},
+						},
+						Net: []src.Connection{
+							connection("", "start", "m", "data"),
+							connection("m", "res", "", "stop"),
+						},
+					},
+				},
+			}
+			for name, entity := range tt.userEnts {
+				mainEntities[name] = entity
+			}
+
+			build := src.Build{
+				EntryModRef: src.ModuleRef{Path: "@"},
+				Modules: map[src.ModuleRef]src.Module{
+					{Path: "@"}: {
+						Packages: map[string]src.Package{
+							"main": {"main": {Entities: mainEntities}},
+						},
+					},
+					{Path: "std", Version: pkg.Version}: {
+						Packages: map[string]src.Package{
+							"builtin": {"streams": {Entities: map[string]src.Entity{
+								"Map": proxyComponent("handler"),
+							}}},
+						},
+					},
+				},
+			}
+
+			prog, err := Generator{}.Generate(build, "main")
+			require.Nil(t, err)
+			require.Equal(t, []ir.FuncCall{
+				{
+					Ref: "inc",
+					IO: ir.FuncIO{
+						In:  []ir.PortAddr{{Path: tt.wantPath + "/in", Port: "data"}},
+						Out: []ir.PortAddr{{Path: tt.wantPath + "/out", Port: "res"}},
+					},
+				},
+			}, prog.Funcs)
+		})
+	}
+}
+
+func componentInterface(in, out string) src.Interface {
+	return src.Interface{
+		IO: src.IO{
+			In:  map[string]src.Port{in: {}},
+			Out: map[string]src.Port{out: {}},
+		},
+	}
+}
+
+func externComponent(ref string) src.Entity {
+	return src.Entity{
+		Kind: src.ComponentEntity,
+		Component: src.Component{
+			Directives: map[src.Directive][]string{compiler.ExternDirective: {ref}},
+			Interface:  componentInterface("data", "res"),
+		},
+	}
+}
+
+// proxyComponent passes data through its only node, which is expected to be injected.
+func proxyComponent(nodeName string) src.Entity {
+	return src.Entity{
+		Kind: src.ComponentEntity,
+		Component: src.Component{
+			Interface: componentInterface("data", "res"),
+			Nodes: map[string]src.Node{
+				nodeName: {EntityRef: core.EntityRef{Name: "IHandler"}},
+			},
+			Net: []src.Connection{
+				connection("", "data", nodeName, "data"),
+				connection(nodeName, "res", "", "res"),
+			},
+		},
+	}
+}
+
+func connection(senderNode, senderPort, receiverNode, receiverPort string) src.Connection {
+	if senderNode == "" {
+		senderNode = "in"
+	}
+	if receiverNode == "" {
+		receiverNode = "out"
+	}
+	return src.Connection{
+		Normal: &src.NormalConnection{
+			SenderSide: src.ConnectionSenderSide{
+				PortAddr: &src.PortAddr{Node: senderNode, Port: senderPort},
+			},
+			ReceiverSide: src.ConnectionReceiverSide{
+				Receivers: []src.ConnectionReceiver{
+					{PortAddr: src.PortAddr{Node: receiverNode, Port: receiverPort}},
+				},
+			},
+		},
+	}
+}
@@ -37,7 +37,14 @@ func getRuntimeFuncRef(component src.Component, nodeTypeArgs []ts.Expr) (string,
 	return "", errors.New("type argument mismatches runtime func directive")
 }
 
-func getRuntimeFuncMsg(node src.Node, scope src.Scope) (*ir.Msg, *compiler.Error) {
+func getRuntimeFuncMsg(component src.Component, node src.Node, scope src.Scope) (*ir.Msg, *compiler.Error) {
+	if _, ok := component.Directives[compiler.TypeInfoDirective]; ok {
+		if len(node.TypeArgs) == 0 {
+			return getTypeInfoMsg(ts.Expr{}), nil
+		}
+		return getTypeInfoMsg(node.TypeArgs[0]), nil
+	}
+
 	args, ok := node.Directives[compiler.BindDirective]
 	if !ok {
 		return nil, nil
@@ -53,3 +60,57 @@ func getRuntimeFuncMsg(node src.Node, scope src.Scope) (*ir.Msg, *compiler.Error
 
 	return getIRMsgBySrcRef(entity.Const, scope.WithLocation(location))
 }
+
+// getTypeInfoMsg describes resolved type expression for runtime funcs that need to know their type argument.
+// Message is a map with "kind" (any, bool, int, float, string, list, map, maybe, struct, enum or union)
+// and, depending on the kind, "elem", "fields", "enum" or "union".
+// Type parameters are already replaced with their constraints by analyzer,
+// everything that can't be described (e.g. recursive types) is described as any.
+func getTypeInfoMsg(expr ts.Expr) *ir.Msg {
+	info := map[string]ir.Msg{}
+
+	switch expr.Lit.Type() {
+	case ts.StructLitType:
+		fields := make(map[string]ir.Msg, len(expr.Lit.Struct))
+		for name, field := range expr.Lit.Struct {
+			fields[name] = *getTypeInfoMsg(field)
+		}
+		info["kind"] = ir.Msg{Type: ir.MsgTypeString, Str: "struct"}
+		info["fields"] = ir.Msg{Type: ir.MsgTypeMap, Map: fields}
+	case ts.EnumLitType:
+		members := make([]ir.Msg, len(expr.Lit.Enum))
+		for i, member := range expr.Lit.Enum {
+			members[i] = ir.Msg{Type: ir.MsgTypeString, Str: member}
+		}
+		info["kind"] = ir.Msg{Type: ir.MsgTypeString, Str: "enum"}
+		info["enum"] = ir.Msg{Type: ir.MsgTypeList, List: members}
+	case ts.UnionLitType:
+		variants := make([]ir.Msg, len(expr.Lit.Union))
+		for i, variant := range expr.Lit.Union {
+			variants[i] = *getTypeInfoMsg(variant)
+		}
+		info["kind"] = ir.Msg{Type: ir.MsgTypeString, Str: "union"}
+		info["union"] = ir.Msg{Type: ir.MsgTypeList, List: variants}
+	default:
+		kind := "any"
+		if expr.Inst != nil && expr.Inst.Ref.Pkg == "" {
+			kind = expr.Inst.Ref.Name
+		}
+
+		switch kind {
+		case "bool", "int", "float", "string":
+		case "list", "map", "maybe":
+			elem := ts.Expr{}
+			if len(expr.Inst.Args) == 1 {
+				elem = expr.Inst.Args[0]
+			}
+			info["elem"] = *getTypeInfoMsg(elem)
+		default:
+			kind = "any"
+		}
+
+		info["kind"] = ir.Msg{Type: ir.MsgTypeString, Str: kind}
+	}
+
+	return &ir.Msg{Type: ir.MsgTypeMap, Map: info}
+}
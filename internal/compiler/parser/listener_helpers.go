@@ -1067,12 +1067,25 @@ func parseCompDef(actx generated.ICompDefContext) (src.Entity, *compiler.Error)
 		return src.Entity{}, err
 	}
 
+	meta := core.Meta{
+		Text: actx.GetText(),
+		Start: core.Position{
+			Line:   actx.GetStart().GetLine(),
+			Column: actx.GetStart().GetColumn(),
+		},
+		Stop: core.Position{
+			Line:   actx.GetStop().GetLine(),
+			Column: actx.GetStop().GetColumn(),
+		},
+	}
+
 	body := actx.CompBody()
 	if body == nil {
 		return src.Entity{
 			Kind: src.ComponentEntity,
 			Component: src.Component{
 				Interface: parsedInterfaceDef,
+				Meta:      meta,
 			},
 		}, nil
 	}
@@ -1094,6 +1107,7 @@ func parseCompDef(actx generated.ICompDefContext) (src.Entity, *compiler.Error)
 			Component: src.Component{
 				Interface: parsedInterfaceDef,
 				Net:       parsedConnections,
+				Meta:      meta,
 			},
 		}, nil
 	}
@@ -1111,6 +1125,7 @@ func parseCompDef(actx generated.ICompDefContext) (src.Entity, *compiler.Error)
 			Interface: parsedInterfaceDef,
 			Nodes:     parsedNodes,
 			Net:       parsedConnections,
+			Meta:      meta,
 		},
 	}, nil
 }
@@ -33,5 +33,6 @@ func processParsedManifest(manifest src.ModuleManifest) src.ModuleManifest {
 	return src.ModuleManifest{
 		LanguageVersion: manifest.LanguageVersion,
 		Deps:            deps,
+		Native:          manifest.Native,
 	}
 }
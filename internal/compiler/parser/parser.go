@@ -41,6 +41,7 @@ func (p Parser) ParseModules(
 		parsedMods[modRef] = src.Module{
 			Manifest: rawMod.Manifest,
 			Packages: parsedPkgs,
+			Native:   rawMod.Native,
 		}
 	}
 
@@ -59,6 +59,16 @@ func (s Scope) IsTopType(expr ts.Expr) bool {
 	return expr.Inst.Ref.Pkg == "" || expr.Inst.Ref.Pkg == "builtin"
 }
 
+func (s Scope) IsIntType(expr ts.Expr) bool {
+	if expr.Inst == nil {
+		return false
+	}
+	if expr.Inst.Ref.Name != "int" {
+		return false
+	}
+	return expr.Inst.Ref.Pkg == "" || expr.Inst.Ref.Pkg == "builtin"
+}
+
 func (s Scope) GetType(ref core.EntityRef) (ts.Def, ts.Scope, error) {
 	entity, location, err := s.Entity(ref)
 	if err != nil {
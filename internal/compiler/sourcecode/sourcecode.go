@@ -18,6 +18,7 @@ type Build struct {
 type Module struct {
 	Manifest ModuleManifest     `json:"manifest,omitempty"`
 	Packages map[string]Package `json:"packages,omitempty"`
+	Native   map[string][]byte  `json:"native,omitempty"` // Go files of Manifest.Native package
 }
 
 func (mod Module) Entity(entityRef core.EntityRef) (entity Entity, filename string, err error) {
@@ -45,6 +46,17 @@ func (mod Module) Files(f func(file File, pkgName, fileName string)) {
 type ModuleManifest struct {
 	LanguageVersion string               `json:"neva,omitempty" yaml:"neva,omitempty"`
 	Deps            map[string]ModuleRef `json:"deps,omitempty" yaml:"deps,omitempty"`
+	Native          NativeFuncs          `json:"native,omitempty" yaml:"native,omitempty"`
+}
+
+// NativeFuncs describes Go package that module ships to implement its own runtime funcs.
+// Package registers every func from Funcs via github.com/nevalang/neva/pkg/runtime.Register
+// in its init function, so module's components can refer to them with #extern.
+// Besides standard library and github.com/nevalang/neva/pkg/..., package can only import
+// modules that runtime itself depends on, other imports are rejected at compile time.
+type NativeFuncs struct {
+	Path  string   `json:"path,omitempty" yaml:"path,omitempty"`   // Relative to module root
+	Funcs []string `json:"funcs,omitempty" yaml:"funcs,omitempty"` // Refs that package registers
 }
 
 type ModuleRef struct {
@@ -0,0 +1,272 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// Debugger is an event listener that can pause the program on port addresses (breakpoints),
+// step through message events and inspect or rewrite message that is currently in flight.
+// It does not interact with the user by itself, frontends (REPL, DAP server) consume its stops.
+type Debugger struct {
+	// world is read-locked by every event and write-locked by a stop,
+	// so the whole network freezes at event granularity while user inspects a message.
+	world sync.RWMutex
+
+	mu          sync.Mutex
+	breakpoints map[runtime.PortAddr]struct{}
+	stepping    bool
+	detached    bool
+
+	stops chan *Stop
+	done  chan struct{}
+}
+
+// StopReason describes why debugger paused the program.
+type StopReason string
+
+const (
+	StopReasonBreakpoint StopReason = "breakpoint"
+	StopReasonStep       StopReason = "step"
+)
+
+// Stop is a paused message event. Frontend can modify Msg and then must call Continue or Step.
+type Stop struct {
+	Reason StopReason
+	Event  runtime.Event
+	Msg    runtime.Msg
+	resume chan bool // true means step to the next event
+}
+
+// Continue resumes the program until the next breakpoint.
+func (s *Stop) Continue() { s.resume <- false }
+
+// Step resumes the program until the next message event.
+func (s *Stop) Step() { s.resume <- true }
+
+func (d *Debugger) Send(event runtime.Event, msg runtime.Msg) runtime.Msg {
+	// received event happens after the fact so there's nothing to intercept
+	if event.Type == runtime.MessageReceivedEvent {
+		d.world.RLock()
+		d.world.RUnlock() //nolint:staticcheck // SA2001 we only wait for current stop to finish
+		return msg
+	}
+
+	reason, ok := d.shouldStop(event)
+	if !ok {
+		d.world.RLock()
+		d.world.RUnlock() //nolint:staticcheck // SA2001
+		return msg
+	}
+
+	d.world.Lock()
+	defer d.world.Unlock()
+
+	stop := &Stop{
+		Reason: reason,
+		Event:  event,
+		Msg:    msg,
+		resume: make(chan bool, 1), // buffered so frontend never blocks after detach
+	}
+
+	select {
+	case d.stops <- stop:
+	case <-d.done:
+		return msg
+	}
+
+	var step bool
+	select {
+	case step = <-stop.resume:
+	case <-d.done:
+		return stop.Msg
+	}
+
+	d.mu.Lock()
+	d.stepping = step
+	d.mu.Unlock()
+
+	return stop.Msg
+}
+
+func (d *Debugger) shouldStop(event runtime.Event) (StopReason, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.detached {
+		return "", false
+	}
+
+	if d.stepping {
+		return StopReasonStep, true
+	}
+
+	for _, addr := range eventPortAddrs(event) {
+		if _, ok := d.breakpoints[addr]; ok {
+			return StopReasonBreakpoint, true
+		}
+	}
+
+	return "", false
+}
+
+// eventPortAddrs returns all port addresses that are involved in the event.
+func eventPortAddrs(event runtime.Event) []runtime.PortAddr {
+	switch event.Type {
+	case runtime.MessageSentEvent:
+		addrs := make([]runtime.PortAddr, 0, len(event.MessageSent.ReceiverPortAddrs)+1)
+		addrs = append(addrs, event.MessageSent.SenderPortAddr)
+		for addr := range event.MessageSent.ReceiverPortAddrs {
+			addrs = append(addrs, addr)
+		}
+		return addrs
+	case runtime.MessagePendingEvent:
+		return []runtime.PortAddr{
+			event.MessagePending.Meta.SenderPortAddr,
+			event.MessagePending.ReceiverPortAddr,
+		}
+	case runtime.MessageReceivedEvent:
+		return []runtime.PortAddr{
+			event.MessageReceived.Meta.SenderPortAddr,
+			event.MessageReceived.ReceiverPortAddr,
+		}
+	}
+	return nil
+}
+
+// Stops returns channel of paused events. Every received stop must be resumed.
+func (d *Debugger) Stops() <-chan *Stop {
+	return d.stops
+}
+
+// Pause makes debugger stop at the next message event.
+func (d *Debugger) Pause() {
+	d.mu.Lock()
+	d.stepping = true
+	d.mu.Unlock()
+}
+
+func (d *Debugger) SetBreakpoint(addr runtime.PortAddr) {
+	d.mu.Lock()
+	d.breakpoints[addr] = struct{}{}
+	d.mu.Unlock()
+}
+
+func (d *Debugger) ClearBreakpoint(addr runtime.PortAddr) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.breakpoints[addr]
+	delete(d.breakpoints, addr)
+	return ok
+}
+
+func (d *Debugger) ClearBreakpoints() {
+	d.mu.Lock()
+	d.breakpoints = map[runtime.PortAddr]struct{}{}
+	d.mu.Unlock()
+}
+
+// Breakpoints returns breakpoints sorted by their string representation.
+func (d *Debugger) Breakpoints() []runtime.PortAddr {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	addrs := make([]runtime.PortAddr, 0, len(d.breakpoints))
+	for addr := range d.breakpoints {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	return addrs
+}
+
+// Detach removes all breakpoints and lets the program run freely. It's safe to call it more than once.
+func (d *Debugger) Detach() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.detached {
+		return
+	}
+	d.detached = true
+	d.stepping = false
+	d.breakpoints = map[runtime.PortAddr]struct{}{}
+	close(d.done)
+}
+
+func NewDebugger() *Debugger {
+	return &Debugger{
+		breakpoints: map[runtime.PortAddr]struct{}{},
+		stops:       make(chan *Stop),
+		done:        make(chan struct{}),
+	}
+}
+
+var ErrInvalidPortAddr = errors.New("port address must be in a form of 'path:port' or 'path:port[idx]'")
+
+// ParsePortAddr parses port address in the same format that runtime.PortAddr.String() produces.
+func ParsePortAddr(s string) (runtime.PortAddr, error) {
+	s = strings.TrimSpace(s)
+
+	colon := strings.LastIndex(s, ":")
+	if colon == -1 {
+		return runtime.PortAddr{}, fmt.Errorf("%w: %v", ErrInvalidPortAddr, s)
+	}
+
+	path, port := s[:colon], s[colon+1:]
+
+	var idx uint64
+	if bracket := strings.Index(port, "["); bracket != -1 {
+		if !strings.HasSuffix(port, "]") {
+			return runtime.PortAddr{}, fmt.Errorf("%w: %v", ErrInvalidPortAddr, s)
+		}
+		var err error
+		idx, err = strconv.ParseUint(port[bracket+1:len(port)-1], 10, 8)
+		if err != nil {
+			return runtime.PortAddr{}, fmt.Errorf("%w: %v", ErrInvalidPortAddr, s)
+		}
+		port = port[:bracket]
+	}
+
+	if path == "" || port == "" {
+		return runtime.PortAddr{}, fmt.Errorf("%w: %v", ErrInvalidPortAddr, s)
+	}
+
+	return runtime.PortAddr{
+		Path: path,
+		Port: port,
+		Idx:  uint8(idx),
+	}, nil
+}
+
+// ParseMsg parses JSON into runtime message.
+// Integer numbers become int messages, other numbers become float messages,
+// arrays become lists and objects become maps.
+func ParseMsg(s string) (runtime.Msg, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(s))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after message")
+	}
+
+	if v == nil {
+		return nil, errors.New("null is not a message")
+	}
+
+	return runtime.MsgFromJSONValue(v)
+}
@@ -0,0 +1,361 @@
+package interpreter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DAPServer exposes debugger via Debug Adapter Protocol so editors can attach to running program.
+// Protocol has no notion of port addresses so they are used as function breakpoint names.
+// There's only one thread and one stack frame - the message event that debugger is stopped at.
+type DAPServer struct {
+	debugger *Debugger
+
+	writeMu sync.Mutex
+	w       io.Writer
+	seq     int
+
+	stopMu sync.Mutex
+	stop   *Stop
+
+	configured     chan struct{}
+	configuredOnce sync.Once
+}
+
+const (
+	dapThreadID       = 1
+	dapFrameID        = 1
+	dapVariablesMsgID = 1
+)
+
+type (
+	dapRequest struct {
+		Seq       int             `json:"seq"`
+		Command   string          `json:"command"`
+		Arguments json.RawMessage `json:"arguments,omitempty"`
+	}
+
+	dapResponse struct {
+		Seq        int    `json:"seq"`
+		Type       string `json:"type"`
+		RequestSeq int    `json:"request_seq"`
+		Success    bool   `json:"success"`
+		Command    string `json:"command"`
+		Message    string `json:"message,omitempty"`
+		Body       any    `json:"body,omitempty"`
+	}
+
+	dapEvent struct {
+		Seq   int    `json:"seq"`
+		Type  string `json:"type"`
+		Event string `json:"event"`
+		Body  any    `json:"body,omitempty"`
+	}
+
+	dapVariable struct {
+		Name               string `json:"name"`
+		Value              string `json:"value"`
+		VariablesReference int    `json:"variablesReference"`
+	}
+)
+
+// Serve handles DAP session on the given connection until it's closed, client disconnects or context is done.
+func (s *DAPServer) Serve(ctx context.Context, rw io.ReadWriter) error {
+	s.w = rw
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case stop := <-s.debugger.Stops():
+				s.stopMu.Lock()
+				s.stop = stop
+				s.stopMu.Unlock()
+				s.event("stopped", map[string]any{
+					"reason":            string(stop.Reason),
+					"description":       stop.Event.String(),
+					"threadId":          dapThreadID,
+					"allThreadsStopped": true,
+				})
+			}
+		}
+	}()
+
+	reader := bufio.NewReader(rw)
+	for {
+		req, err := readDAPRequest(reader)
+		if err != nil {
+			s.debugger.Detach()
+			s.markConfigured()
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if !s.handle(req) {
+			return nil
+		}
+	}
+}
+
+// Configured is closed when client has finished configuration (e.g. set breakpoints) and program can start.
+func (s *DAPServer) Configured() <-chan struct{} {
+	return s.configured
+}
+
+// Terminate notifies client that program has finished.
+func (s *DAPServer) Terminate() {
+	s.event("terminated", nil)
+}
+
+func (s *DAPServer) markConfigured() {
+	s.configuredOnce.Do(func() { close(s.configured) })
+}
+
+// handle returns false if session must be ended.
+func (s *DAPServer) handle(req dapRequest) bool { //nolint:funlen
+	switch req.Command {
+	case "initialize":
+		s.respond(req, map[string]any{
+			"supportsConfigurationDoneRequest": true,
+			"supportsFunctionBreakpoints":      true,
+			"supportsSetVariable":              true,
+		})
+		s.event("initialized", nil)
+	case "launch", "attach":
+		var args struct {
+			StopOnEntry bool `json:"stopOnEntry"`
+		}
+		_ = json.Unmarshal(req.Arguments, &args)
+		if args.StopOnEntry {
+			s.debugger.Pause()
+		}
+		s.respond(req, nil)
+	case "setBreakpoints": // source breakpoints are not supported
+		s.respond(req, map[string]any{"breakpoints": []any{}})
+	case "setFunctionBreakpoints":
+		var args struct {
+			Breakpoints []struct {
+				Name string `json:"name"`
+			} `json:"breakpoints"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			s.fail(req, err)
+			return true
+		}
+		s.debugger.ClearBreakpoints()
+		result := make([]map[string]any, 0, len(args.Breakpoints))
+		for _, bp := range args.Breakpoints {
+			addr, err := ParsePortAddr(bp.Name)
+			if err != nil {
+				result = append(result, map[string]any{"verified": false, "message": err.Error()})
+				continue
+			}
+			s.debugger.SetBreakpoint(addr)
+			result = append(result, map[string]any{"verified": true})
+		}
+		s.respond(req, map[string]any{"breakpoints": result})
+	case "configurationDone":
+		s.respond(req, nil)
+		s.markConfigured()
+	case "threads":
+		s.respond(req, map[string]any{
+			"threads": []map[string]any{{"id": dapThreadID, "name": "main"}},
+		})
+	case "stackTrace":
+		frames := []map[string]any{}
+		if stop := s.currentStop(); stop != nil {
+			frames = append(frames, map[string]any{
+				"id":     dapFrameID,
+				"name":   stop.Event.String(),
+				"line":   0,
+				"column": 0,
+			})
+		}
+		s.respond(req, map[string]any{"stackFrames": frames, "totalFrames": len(frames)})
+	case "scopes":
+		s.respond(req, map[string]any{
+			"scopes": []map[string]any{{
+				"name":               "Message",
+				"variablesReference": dapVariablesMsgID,
+				"expensive":          false,
+			}},
+		})
+	case "variables":
+		s.respond(req, map[string]any{"variables": s.variables()})
+	case "setVariable":
+		var args struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			s.fail(req, err)
+			return true
+		}
+		stop := s.currentStop()
+		if stop == nil || args.Name != "msg" {
+			s.fail(req, fmt.Errorf("only 'msg' can be modified while program is stopped"))
+			return true
+		}
+		msg, err := ParseMsg(args.Value)
+		if err != nil {
+			s.fail(req, err)
+			return true
+		}
+		stop.Msg = msg
+		s.respond(req, map[string]any{"value": msg.String()})
+	case "evaluate":
+		stop := s.currentStop()
+		if stop == nil {
+			s.fail(req, fmt.Errorf("program is not stopped"))
+			return true
+		}
+		s.respond(req, map[string]any{"result": fmt.Sprint(stop.Msg), "variablesReference": 0})
+	case "continue":
+		if stop := s.takeStop(); stop != nil {
+			stop.Continue()
+		}
+		s.respond(req, map[string]any{"allThreadsContinued": true})
+	case "next", "stepIn", "stepOut":
+		if stop := s.takeStop(); stop != nil {
+			stop.Step()
+		}
+		s.respond(req, nil)
+	case "pause":
+		s.debugger.Pause()
+		s.respond(req, nil)
+	case "disconnect", "terminate":
+		s.debugger.Detach()
+		s.markConfigured()
+		s.respond(req, nil)
+		return false
+	default:
+		s.fail(req, fmt.Errorf("unsupported command: %v", req.Command))
+	}
+
+	return true
+}
+
+func (s *DAPServer) variables() []dapVariable {
+	stop := s.currentStop()
+	if stop == nil {
+		return []dapVariable{}
+	}
+
+	// first address is always sender and the rest are receivers
+	addrs := eventPortAddrs(stop.Event)
+	receivers := make([]string, 0, len(addrs)-1)
+	for _, addr := range addrs[1:] {
+		receivers = append(receivers, addr.String())
+	}
+
+	return []dapVariable{
+		{Name: "event", Value: stop.Event.Type.String()},
+		{Name: "sender", Value: addrs[0].String()},
+		{Name: "receiver", Value: strings.Join(receivers, ", ")},
+		{Name: "msg", Value: fmt.Sprint(stop.Msg)},
+	}
+}
+
+func (s *DAPServer) currentStop() *Stop {
+	s.stopMu.Lock()
+	defer s.stopMu.Unlock()
+	return s.stop
+}
+
+func (s *DAPServer) takeStop() *Stop {
+	s.stopMu.Lock()
+	defer s.stopMu.Unlock()
+	stop := s.stop
+	s.stop = nil
+	return stop
+}
+
+func (s *DAPServer) respond(req dapRequest, body any) {
+	s.write(func(seq int) any {
+		return dapResponse{
+			Seq:        seq,
+			Type:       "response",
+			RequestSeq: req.Seq,
+			Success:    true,
+			Command:    req.Command,
+			Body:       body,
+		}
+	})
+}
+
+func (s *DAPServer) fail(req dapRequest, err error) {
+	s.write(func(seq int) any {
+		return dapResponse{
+			Seq:        seq,
+			Type:       "response",
+			RequestSeq: req.Seq,
+			Success:    false,
+			Command:    req.Command,
+			Message:    err.Error(),
+		}
+	})
+}
+
+func (s *DAPServer) event(name string, body any) {
+	s.write(func(seq int) any {
+		return dapEvent{
+			Seq:   seq,
+			Type:  "event",
+			Event: name,
+			Body:  body,
+		}
+	})
+}
+
+func (s *DAPServer) write(f func(seq int) any) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+	bb, err := json.Marshal(f(s.seq))
+	if err != nil {
+		panic(err) // all messages are built from json-friendly values
+	}
+
+	fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(bb), bb)
+}
+
+func readDAPRequest(r *bufio.Reader) (dapRequest, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return dapRequest{}, err
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return dapRequest{}, fmt.Errorf("invalid content length: %w", err)
+	}
+
+	bb := make([]byte, length)
+	if _, err := io.ReadFull(r, bb); err != nil {
+		return dapRequest{}, err
+	}
+
+	var req dapRequest
+	if err := json.Unmarshal(bb, &req); err != nil {
+		return dapRequest{}, err
+	}
+
+	return req, nil
+}
+
+func NewDAPServer(debugger *Debugger) *DAPServer {
+	return &DAPServer{
+		debugger:   debugger,
+		configured: make(chan struct{}),
+	}
+}
@@ -0,0 +1,163 @@
+package interpreter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"testing"
+
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+// dapClient is a minimal editor side of the protocol.
+type dapClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+	seq  int
+}
+
+func (c *dapClient) request(command string, args any) {
+	c.t.Helper()
+
+	c.seq++
+	bb, err := json.Marshal(map[string]any{
+		"seq":       c.seq,
+		"type":      "request",
+		"command":   command,
+		"arguments": args,
+	})
+	require.NoError(c.t, err)
+
+	_, err = fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(bb), bb)
+	require.NoError(c.t, err)
+}
+
+func (c *dapClient) read() map[string]any {
+	c.t.Helper()
+
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	require.NoError(c.t, err)
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	require.NoError(c.t, err)
+
+	bb := make([]byte, length)
+	_, err = io.ReadFull(c.r, bb)
+	require.NoError(c.t, err)
+
+	var msg map[string]any
+	require.NoError(c.t, json.Unmarshal(bb, &msg))
+
+	return msg
+}
+
+// response reads the next message and checks that it's a response to the last request.
+func (c *dapClient) response(command string, success bool) map[string]any {
+	c.t.Helper()
+
+	msg := c.read()
+	require.Equal(c.t, "response", msg["type"], msg)
+	require.Equal(c.t, command, msg["command"], msg)
+	require.Equal(c.t, float64(c.seq), msg["request_seq"], msg)
+	require.Equal(c.t, success, msg["success"], msg)
+
+	body, _ := msg["body"].(map[string]any)
+	return body
+}
+
+func (c *dapClient) event(name string) map[string]any {
+	c.t.Helper()
+
+	msg := c.read()
+	require.Equal(c.t, "event", msg["type"], msg)
+	require.Equal(c.t, name, msg["event"], msg)
+
+	body, _ := msg["body"].(map[string]any)
+	return body
+}
+
+func TestDAPServer(t *testing.T) {
+	d := NewDebugger()
+	server := NewDAPServer(d)
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(context.Background(), serverConn) }()
+
+	c := &dapClient{t: t, conn: clientConn, r: bufio.NewReader(clientConn)}
+
+	c.request("initialize", map[string]any{"adapterID": "neva"})
+	require.Equal(t, true, c.response("initialize", true)["supportsFunctionBreakpoints"])
+	c.event("initialized")
+
+	c.request("launch", map[string]any{})
+	c.response("launch", true)
+
+	c.request("setFunctionBreakpoints", map[string]any{
+		"breakpoints": []map[string]any{{"name": "println/in:data[0]"}, {"name": "nonsense"}},
+	})
+	bps := c.response("setFunctionBreakpoints", true)["breakpoints"].([]any)
+	require.Len(t, bps, 2)
+	require.Equal(t, true, bps[0].(map[string]any)["verified"])
+	require.Equal(t, false, bps[1].(map[string]any)["verified"])
+	require.Equal(t, []runtime.PortAddr{testReceiver}, d.Breakpoints())
+
+	c.request("configurationDone", nil)
+	c.response("configurationDone", true)
+	<-server.Configured()
+
+	c.request("evaluate", map[string]any{"expression": "msg"})
+	c.response("evaluate", false)
+
+	res := sendAsync(d, sentEvent(testSender, testReceiver), runtime.NewStrMsg("hi"))
+	require.Equal(t, "breakpoint", c.event("stopped")["reason"])
+
+	c.request("variables", map[string]any{"variablesReference": dapVariablesMsgID})
+	require.Equal(
+		t,
+		[]any{
+			map[string]any{"name": "event", "value": "sent", "variablesReference": float64(0)},
+			map[string]any{"name": "sender", "value": "in:start[0]", "variablesReference": float64(0)},
+			map[string]any{"name": "receiver", "value": "println/in:data[0]", "variablesReference": float64(0)},
+			map[string]any{"name": "msg", "value": "hi", "variablesReference": float64(0)},
+		},
+		c.response("variables", true)["variables"],
+	)
+
+	c.request("setVariable", map[string]any{"variablesReference": dapVariablesMsgID, "name": "event", "value": "1"})
+	c.response("setVariable", false)
+
+	c.request("setVariable", map[string]any{"variablesReference": dapVariablesMsgID, "name": "msg", "value": `"bye"`})
+	require.Equal(t, "bye", c.response("setVariable", true)["value"])
+
+	c.request("next", map[string]any{"threadId": dapThreadID})
+	c.response("next", true)
+	require.Equal(t, runtime.NewStrMsg("bye"), requirePassed(t, res))
+
+	// stepping stops at the very next event
+	res = sendAsync(d, sentEvent(testSender, testOther), runtime.NewIntMsg(1))
+	require.Equal(t, "step", c.event("stopped")["reason"])
+
+	c.request("continue", map[string]any{"threadId": dapThreadID})
+	require.Equal(t, true, c.response("continue", true)["allThreadsContinued"])
+	requirePassed(t, res)
+
+	c.request("stepBack", nil)
+	c.response("stepBack", false)
+
+	c.request("disconnect", nil)
+	c.response("disconnect", true)
+	require.NoError(t, <-served)
+
+	// disconnected client must not leave the program paused
+	requirePassed(t, sendAsync(d, sentEvent(testSender, testReceiver), runtime.NewIntMsg(1)))
+}
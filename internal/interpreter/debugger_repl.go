@@ -0,0 +1,132 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const replHelp = `commands:
+  break <path:port[idx]>   (b)  set breakpoint on port address
+  delete <path:port[idx]>  (d)  remove breakpoint
+  breakpoints              (bl) list breakpoints
+  print                    (p)  show current event and message
+  set <json>                    replace current message, e.g. set {"text": "hi"}
+  step                     (s)  resume until the next message event
+  continue                 (c)  resume until the next breakpoint
+  quit                     (q)  remove all breakpoints and let the program finish
+  help                     (h)  show this message
+`
+
+// RunREPL interacts with the user via line-oriented commands every time debugger stops.
+// It only reads the input while the program is paused and never reads past the end of the command,
+// so program can still use stdin in between.
+func RunREPL(ctx context.Context, d *Debugger, in io.Reader, out io.Writer) {
+	for {
+		var stop *Stop
+		select {
+		case <-ctx.Done():
+			return
+		case stop = <-d.Stops():
+		}
+
+		fmt.Fprintf(out, "stopped (%v) at %v\n", stop.Reason, stop.Event)
+		fmt.Fprintf(out, "msg: %v\n", stop.Msg)
+
+		if !replHandleStop(in, d, stop, out) {
+			d.Detach()
+			stop.Continue()
+			return
+		}
+	}
+}
+
+// replHandleStop reads commands until one of them resumes the program.
+// It returns false if user wants to quit or input is closed.
+func replHandleStop(in io.Reader, d *Debugger, stop *Stop, out io.Writer) bool {
+	for {
+		fmt.Fprint(out, "(neva) ")
+
+		line, ok := readLine(in)
+		if !ok {
+			return false
+		}
+
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		arg = strings.TrimSpace(arg)
+
+		switch cmd {
+		case "":
+			continue
+		case "break", "b":
+			addr, err := ParsePortAddr(arg)
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			d.SetBreakpoint(addr)
+			fmt.Fprintf(out, "breakpoint set: %v\n", addr)
+		case "delete", "d":
+			addr, err := ParsePortAddr(arg)
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			if !d.ClearBreakpoint(addr) {
+				fmt.Fprintf(out, "no breakpoint at %v\n", addr)
+				continue
+			}
+			fmt.Fprintf(out, "breakpoint removed: %v\n", addr)
+		case "breakpoints", "bl":
+			for _, addr := range d.Breakpoints() {
+				fmt.Fprintln(out, addr)
+			}
+		case "print", "p":
+			fmt.Fprintf(out, "%v\nmsg: %v\n", stop.Event, stop.Msg)
+		case "set":
+			msg, err := ParseMsg(arg)
+			if err != nil {
+				fmt.Fprintf(out, "invalid message: %v\n", err)
+				continue
+			}
+			stop.Msg = msg
+			fmt.Fprintf(out, "msg: %v\n", stop.Msg)
+		case "step", "s":
+			stop.Step()
+			return true
+		case "continue", "c":
+			stop.Continue()
+			return true
+		case "quit", "q":
+			return false
+		case "help", "h":
+			fmt.Fprint(out, replHelp)
+		default:
+			fmt.Fprintf(out, "unknown command '%v', type 'help' to see available commands\n", cmd)
+		}
+	}
+}
+
+// readLine reads input byte by byte up to the newline.
+// Unlike buffered readers it leaves everything after the line to the program.
+// It returns false if input is closed before anything is read.
+func readLine(in io.Reader) (string, bool) {
+	var (
+		line []byte
+		b    [1]byte
+	)
+
+	for {
+		n, err := in.Read(b[:])
+		if n == 1 {
+			if b[0] == '\n' {
+				return string(line), true
+			}
+			line = append(line, b[0])
+		}
+		if err != nil {
+			return string(line), len(line) > 0
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package interpreter
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortAddr(t *testing.T) {
+	tests := []struct {
+		in   string
+		want runtime.PortAddr
+	}{
+		{in: "println/in:data[0]", want: runtime.PortAddr{Path: "println/in", Port: "data"}},
+		{in: "in:start", want: runtime.PortAddr{Path: "in", Port: "start"}},
+		{in: " sub/node/out:res[3] ", want: runtime.PortAddr{Path: "sub/node/out", Port: "res", Idx: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePortAddr(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, in := range []string{"", "println", ":data", "println/in:", "in:x[", "in:x[a]", "in:x[256]", "in:x[1"} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, err := ParsePortAddr(in)
+			require.ErrorIs(t, err, ErrInvalidPortAddr)
+		})
+	}
+}
+
+func TestParseMsg(t *testing.T) {
+	tests := []struct {
+		in   string
+		want runtime.Msg
+	}{
+		{in: `true`, want: runtime.NewBoolMsg(true)},
+		{in: `"hi"`, want: runtime.NewStrMsg("hi")},
+		{in: `42`, want: runtime.NewIntMsg(42)},
+		{in: `-1.5`, want: runtime.NewFloatMsg(-1.5)},
+		{in: `[1, "a"]`, want: runtime.NewListMsg(runtime.NewIntMsg(1), runtime.NewStrMsg("a"))},
+		{
+			in: `{"n": 1, "l": [2.5]}`,
+			want: runtime.NewMapMsg(map[string]runtime.Msg{
+				"n": runtime.NewIntMsg(1),
+				"l": runtime.NewListMsg(runtime.NewFloatMsg(2.5)),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMsg(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, in := range []string{``, `{`, `1 2`, `null`} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, err := ParseMsg(in)
+			require.Error(t, err)
+		})
+	}
+}
+
+var (
+	testSender   = runtime.PortAddr{Path: "in", Port: "start"}
+	testReceiver = runtime.PortAddr{Path: "println/in", Port: "data"}
+	testOther    = runtime.PortAddr{Path: "del/in", Port: "msg"}
+)
+
+func sentEvent(sender, receiver runtime.PortAddr) runtime.Event {
+	return runtime.Event{
+		Type: runtime.MessageSentEvent,
+		MessageSent: &runtime.EventMessageSent{
+			SenderPortAddr:    sender,
+			ReceiverPortAddrs: map[runtime.PortAddr]struct{}{receiver: {}},
+		},
+	}
+}
+
+func receivedEvent(sender, receiver runtime.PortAddr) runtime.Event {
+	return runtime.Event{
+		Type: runtime.MessageReceivedEvent,
+		MessageReceived: &runtime.EventMessageReceived{
+			Meta:             runtime.ConnectionMeta{SenderPortAddr: sender},
+			ReceiverPortAddr: receiver,
+		},
+	}
+}
+
+// sendAsync passes event to debugger the way connector does it, without blocking the test.
+func sendAsync(d *Debugger, event runtime.Event, msg runtime.Msg) <-chan runtime.Msg {
+	res := make(chan runtime.Msg, 1)
+	go func() { res <- d.Send(event, msg) }()
+	return res
+}
+
+func requireStop(t *testing.T, d *Debugger, reason StopReason) *Stop {
+	t.Helper()
+	select {
+	case stop := <-d.Stops():
+		require.Equal(t, reason, stop.Reason)
+		return stop
+	case <-time.After(time.Second):
+		t.Fatal("debugger didn't stop")
+	}
+	return nil
+}
+
+func requirePassed(t *testing.T, res <-chan runtime.Msg) runtime.Msg {
+	t.Helper()
+	select {
+	case msg := <-res:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("debugger didn't let the message through")
+	}
+	return nil
+}
+
+func TestDebugger_Breakpoint(t *testing.T) {
+	d := NewDebugger()
+	d.SetBreakpoint(testReceiver)
+
+	msg := runtime.NewStrMsg("hi")
+
+	// other ports are not affected
+	require.Equal(t, msg, requirePassed(t, sendAsync(d, sentEvent(testSender, testOther), msg)))
+
+	// received event happens after the fact and never stops
+	require.Equal(t, msg, requirePassed(t, sendAsync(d, receivedEvent(testSender, testReceiver), msg)))
+
+	res := sendAsync(d, sentEvent(testSender, testReceiver), msg)
+	stop := requireStop(t, d, StopReasonBreakpoint)
+	require.Equal(t, msg, stop.Msg)
+
+	stop.Msg = runtime.NewStrMsg("bye")
+	stop.Continue()
+	require.Equal(t, runtime.NewStrMsg("bye"), requirePassed(t, res))
+
+	// continue doesn't stop until the next breakpoint
+	require.Equal(t, msg, requirePassed(t, sendAsync(d, sentEvent(testSender, testOther), msg)))
+
+	require.True(t, d.ClearBreakpoint(testReceiver))
+	require.False(t, d.ClearBreakpoint(testReceiver))
+	require.Equal(t, msg, requirePassed(t, sendAsync(d, sentEvent(testSender, testReceiver), msg)))
+}
+
+func TestDebugger_Step(t *testing.T) {
+	d := NewDebugger()
+	d.Pause()
+
+	msg := runtime.NewIntMsg(1)
+
+	res := sendAsync(d, sentEvent(testSender, testOther), msg)
+	requireStop(t, d, StopReasonStep).Step()
+	requirePassed(t, res)
+
+	// step stops at the very next event wherever it is
+	res = sendAsync(d, sentEvent(testSender, testReceiver), msg)
+	requireStop(t, d, StopReasonStep).Continue()
+	requirePassed(t, res)
+
+	requirePassed(t, sendAsync(d, sentEvent(testSender, testOther), msg))
+}
+
+func TestDebugger_Detach(t *testing.T) {
+	d := NewDebugger()
+	d.SetBreakpoint(testReceiver)
+
+	msg := runtime.NewIntMsg(1)
+
+	res := sendAsync(d, sentEvent(testSender, testReceiver), msg)
+	requireStop(t, d, StopReasonBreakpoint)
+
+	d.Detach()
+	d.Detach()
+
+	require.Equal(t, msg, requirePassed(t, res))
+	require.Empty(t, d.Breakpoints())
+	requirePassed(t, sendAsync(d, sentEvent(testSender, testReceiver), msg))
+}
+
+func TestRunREPL(t *testing.T) {
+	d := NewDebugger()
+	d.Pause()
+
+	in := strings.NewReader(strings.Join([]string{
+		"",
+		"b println/in:data[0]",
+		"b nonsense",
+		"bl",
+		`set {"text": `,
+		`set "bye"`,
+		"p",
+		"c",
+		"program input",
+		"",
+	}, "\n"))
+
+	var out strings.Builder
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		RunREPL(ctx, d, in, &out)
+		close(done)
+	}()
+
+	msg := requirePassed(t, sendAsync(d, sentEvent(testSender, testOther), runtime.NewStrMsg("hi")))
+	require.Equal(t, runtime.NewStrMsg("bye"), msg)
+	require.Equal(t, []runtime.PortAddr{testReceiver}, d.Breakpoints())
+
+	cancel()
+	<-done
+
+	// everything after the command that resumed the program is left for the program
+	rest, err := io.ReadAll(in)
+	require.NoError(t, err)
+	require.Equal(t, "program input\n", string(rest))
+
+	require.Contains(t, out.String(), "stopped (step) at")
+	require.Contains(t, out.String(), "breakpoint set: println/in:data[0]")
+	require.Contains(t, out.String(), ErrInvalidPortAddr.Error())
+	require.Contains(t, out.String(), "invalid message")
+	require.Contains(t, out.String(), "msg: bye")
+}
+
+func TestRunREPL_Quit(t *testing.T) {
+	d := NewDebugger()
+	d.SetBreakpoint(testReceiver)
+
+	done := make(chan struct{})
+	go func() {
+		RunREPL(context.Background(), d, strings.NewReader("q\n"), io.Discard)
+		close(done)
+	}()
+
+	msg := runtime.NewIntMsg(1)
+	requirePassed(t, sendAsync(d, sentEvent(testSender, testReceiver), msg))
+	<-done
+
+	require.Empty(t, d.Breakpoints())
+	requirePassed(t, sendAsync(d, sentEvent(testSender, testReceiver), msg))
+}
+
+func TestReadLine(t *testing.T) {
+	in := strings.NewReader("first\nlast")
+
+	line, ok := readLine(in)
+	require.True(t, ok)
+	require.Equal(t, "first", line)
+
+	line, ok = readLine(in)
+	require.True(t, ok)
+	require.Equal(t, "last", line)
+
+	_, ok = readLine(in)
+	require.False(t, ok)
+}
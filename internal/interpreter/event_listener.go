@@ -9,8 +9,13 @@ import (
 type DebugEventListener struct{}
 
 func (e DebugEventListener) Send(event runtime.Event, msg runtime.Msg) runtime.Msg {
-	if event.Type == runtime.MessageSentEvent {
-		fmt.Println(event, msg)
+	if event.Type != runtime.MessageSentEvent {
+		return msg
 	}
+	if loc := event.MessageSent.SenderLocation; loc.File != "" {
+		fmt.Println(event, msg, "//", loc, loc.Component)
+		return msg
+	}
+	fmt.Println(event, msg)
 	return msg
 }
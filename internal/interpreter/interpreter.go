@@ -16,6 +16,9 @@ type Interpreter struct {
 	compiler compiler.Compiler
 	runtime  runtime.Runtime
 	adapter  adapter.Adapter
+	shutdown runtime.ShutdownPolicy
+	observed bool   // custom connector or watchers are used
+	seed     *int64 // seed of seeded connector, if it's the only reason of observed
 }
 
 func (i Interpreter) Interpret(ctx context.Context, workdirPath string, mainPkgName string) *compiler.Error {
@@ -28,6 +31,18 @@ func (i Interpreter) Interpret(ctx context.Context, workdirPath string, mainPkgN
 		}.Wrap(compilerErr)
 	}
 
+	if len(irProg.Native) > 0 {
+		if err := i.runNative(ctx, irProg); err != nil {
+			return &compiler.Error{
+				Err: err,
+				Location: &sourcecode.Location{
+					PkgName: mainPkgName,
+				},
+			}
+		}
+		return nil
+	}
+
 	rprog, err := i.adapter.Adapt(irProg)
 	if err != nil {
 		return &compiler.Error{
@@ -38,7 +53,7 @@ func (i Interpreter) Interpret(ctx context.Context, workdirPath string, mainPkgN
 		}
 	}
 
-	if err := i.runtime.Run(ctx, rprog); err != nil {
+	if err := i.runtime.Run(ctx, rprog, i.shutdown); err != nil {
 		return &compiler.Error{
 			Err: err,
 			Location: &sourcecode.Location{
@@ -50,26 +65,49 @@ func (i Interpreter) Interpret(ctx context.Context, workdirPath string, mainPkgN
 	return nil
 }
 
+// New creates interpreter. If connector is nil, default one is used.
+// Zero shutdown policy means interpreter doesn't handle signals.
+// Programs with native funcs can only be run with default connector and without watchers, see also NewSeeded.
 func New(
 	builder builder.Builder,
 	compiler compiler.Compiler,
-	isDebug bool,
+	connector runtime.Connector,
+	shutdown runtime.ShutdownPolicy,
+	watchers ...runtime.Watcher,
 ) Interpreter {
-	var connector runtime.Connector
-	if isDebug {
-		connector = runtime.NewConnector(DebugEventListener{})
-	} else {
+	observed := connector != nil || len(watchers) > 0
+	if connector == nil {
 		connector = runtime.NewDefaultConnector()
 	}
 	return Interpreter{
 		builder:  builder,
 		compiler: compiler,
 		adapter:  adapter.NewAdapter(),
+		shutdown: shutdown,
+		observed: observed,
 		runtime: runtime.New(
 			connector,
 			runtime.MustNewFuncRunner(
 				funcs.CreatorRegistry(),
 			),
+			watchers...,
 		),
 	}
 }
+
+// NewSeeded creates interpreter that uses runtime.SeededConnector without listeners.
+// Unlike other custom connectors it can run programs with native funcs,
+// their executable gets the seed as NEVA_SEED and orders messages the same way.
+func NewSeeded(
+	builder builder.Builder,
+	compiler compiler.Compiler,
+	seed int64,
+	shutdown runtime.ShutdownPolicy,
+	watchers ...runtime.Watcher,
+) Interpreter {
+	intr := New(builder, compiler, runtime.NewSeededConnector(runtime.EmptyListener{}, seed), shutdown, watchers...)
+	if len(watchers) == 0 {
+		intr.seed = &seed
+	}
+	return intr
+}
@@ -0,0 +1,262 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// Metrics is an event listener that collects per-port statistics:
+// how many messages were sent and received, how many messages wait in port's buffer
+// and how long messages were pending before receiver took them.
+// Queue depth is only known for ports of the program that metrics watch (see runtime.Watcher).
+type Metrics struct {
+	mu      sync.Mutex
+	ports   map[runtime.PortAddr]*portMetrics
+	pending map[[2]runtime.PortAddr][]time.Time // sender, receiver -> when messages became pending
+	chans   runtime.Ports
+}
+
+type portMetrics struct {
+	sent     uint64
+	received uint64
+
+	bufSize  int
+	queueLen int // last sampled number of messages in the buffer
+	queueMax int
+
+	latencyCount uint64
+	latencySum   time.Duration
+	latencyMax   time.Duration
+}
+
+func (m *Metrics) Send(event runtime.Event, msg runtime.Msg) runtime.Msg {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch event.Type {
+	case runtime.MessageSentEvent:
+		m.port(event.MessageSent.SenderPortAddr).sent++
+	case runtime.MessagePendingEvent:
+		pair := [2]runtime.PortAddr{event.MessagePending.Meta.SenderPortAddr, event.MessagePending.ReceiverPortAddr}
+		m.pending[pair] = append(m.pending[pair], now)
+	case runtime.MessageReceivedEvent:
+		receiver := m.port(event.MessageReceived.ReceiverPortAddr)
+		receiver.received++
+
+		pair := [2]runtime.PortAddr{event.MessageReceived.Meta.SenderPortAddr, event.MessageReceived.ReceiverPortAddr}
+		since := m.pending[pair]
+		if len(since) == 0 {
+			break
+		}
+		m.pending[pair] = since[1:]
+
+		latency := now.Sub(since[0])
+		receiver.latencyCount++
+		receiver.latencySum += latency
+		if latency > receiver.latencyMax {
+			receiver.latencyMax = latency
+		}
+	}
+
+	return msg
+}
+
+// Watch implements runtime.Watcher so metrics can sample port buffers.
+// Buffer size of each port is its channel's capacity.
+func (m *Metrics) Watch(ctx context.Context, prog runtime.Program) error {
+	m.mu.Lock()
+	m.chans = prog.Ports
+	for addr, ch := range prog.Ports {
+		m.port(addr).bufSize = cap(ch)
+	}
+	m.mu.Unlock()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (m *Metrics) port(addr runtime.PortAddr) *portMetrics {
+	p, ok := m.ports[addr]
+	if !ok {
+		p = &portMetrics{}
+		m.ports[addr] = p
+	}
+	return p
+}
+
+// Sample records current number of messages in every port's buffer.
+func (m *Metrics) Sample() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sample()
+}
+
+func (m *Metrics) sample() {
+	for addr, ch := range m.chans {
+		p := m.port(addr)
+		p.queueLen = len(ch)
+		if p.queueLen > p.queueMax {
+			p.queueMax = p.queueLen
+		}
+	}
+}
+
+// sortedAddrs returns addresses of ports that have something to report.
+func (m *Metrics) sortedAddrs() []runtime.PortAddr {
+	addrs := make([]runtime.PortAddr, 0, len(m.ports))
+	for addr, p := range m.ports {
+		if p.sent == 0 && p.received == 0 && p.queueMax == 0 {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+	return addrs
+}
+
+// WriteSummary samples buffers and writes human-readable table of all active ports.
+func (m *Metrics) WriteSummary(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sample()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PORT\tSENT\tRECEIVED\tQUEUE\tQUEUE MAX\tBUF\tAVG WAIT\tMAX WAIT")
+	for _, addr := range m.sortedAddrs() {
+		p := m.ports[addr]
+		var avg time.Duration
+		if p.latencyCount > 0 {
+			avg = p.latencySum / time.Duration(p.latencyCount)
+		}
+		fmt.Fprintf(
+			tw,
+			"%v\t%d\t%d\t%d\t%d\t%d\t%v\t%v\n",
+			addr, p.sent, p.received, p.queueLen, p.queueMax, p.bufSize, avg, p.latencyMax,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// WritePrometheus samples buffers and writes metrics in Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sample()
+
+	addrs := m.sortedAddrs()
+
+	// sample is a line of the metric family, summaries have two of them: _sum and _count
+	type sample struct {
+		suffix string
+		value  func(p *portMetrics) any
+	}
+
+	type metric struct {
+		name, help, kind string
+		samples          []sample
+	}
+
+	single := func(value func(p *portMetrics) any) []sample {
+		return []sample{{"", value}}
+	}
+
+	metrics := []metric{
+		{
+			"neva_port_messages_sent_total", "Number of messages sent from the port.", "counter",
+			single(func(p *portMetrics) any { return p.sent }),
+		},
+		{
+			"neva_port_messages_received_total", "Number of messages received by the port.", "counter",
+			single(func(p *portMetrics) any { return p.received }),
+		},
+		{
+			"neva_port_queue_length", "Number of messages in the port's buffer at the moment of scraping.", "gauge",
+			single(func(p *portMetrics) any { return p.queueLen }),
+		},
+		{
+			"neva_port_queue_length_max", "Maximum observed number of messages in the port's buffer.", "gauge",
+			single(func(p *portMetrics) any { return p.queueMax }),
+		},
+		{
+			"neva_port_buffer_size", "Capacity of the port's buffer.", "gauge",
+			single(func(p *portMetrics) any { return p.bufSize }),
+		},
+		{
+			"neva_port_pending_seconds", "Time messages were pending before receiver took them.", "summary",
+			[]sample{
+				{"_sum", func(p *portMetrics) any { return p.latencySum.Seconds() }},
+				{"_count", func(p *portMetrics) any { return p.latencyCount }},
+			},
+		},
+		{
+			"neva_port_pending_max_seconds", "Maximum time message was pending before receiver took it.", "gauge",
+			single(func(p *portMetrics) any { return p.latencyMax.Seconds() }),
+		},
+	}
+
+	var b strings.Builder
+	for _, metric := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for _, addr := range addrs {
+			for _, sample := range metric.samples {
+				fmt.Fprintf(
+					&b,
+					"%s%s{path=%q,port=%q,idx=\"%d\"} %v\n",
+					metric.name, sample.suffix, addr.Path, addr.Port, addr.Idx, sample.value(m.ports[addr]),
+				)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ServeHTTP serves metrics in Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := m.WritePrometheus(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Report writes summary to w every interval until context is done.
+func (m *Metrics) Report(ctx context.Context, w io.Writer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.WriteSummary(w); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		ports:   map[runtime.PortAddr]*portMetrics{},
+		pending: map[[2]runtime.PortAddr][]time.Time{},
+	}
+}
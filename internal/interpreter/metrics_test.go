@@ -0,0 +1,74 @@
+package interpreter
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	sender := runtime.PortAddr{Path: "in", Port: "start"}
+	receiver := runtime.PortAddr{Path: "println/in", Port: "data"}
+	meta := runtime.ConnectionMeta{
+		SenderPortAddr:    sender,
+		ReceiverPortAddrs: []runtime.PortAddr{receiver},
+	}
+
+	m := NewMetrics()
+	m.Send(runtime.Event{
+		Type:        runtime.MessageSentEvent,
+		MessageSent: &runtime.EventMessageSent{SenderPortAddr: sender},
+	}, nil)
+	m.Send(runtime.Event{
+		Type:           runtime.MessagePendingEvent,
+		MessagePending: &runtime.EventMessagePending{Meta: meta, ReceiverPortAddr: receiver},
+	}, nil)
+	m.Send(runtime.Event{
+		Type:            runtime.MessageReceivedEvent,
+		MessageReceived: &runtime.EventMessageReceived{Meta: meta, ReceiverPortAddr: receiver},
+	}, nil)
+
+	var b strings.Builder
+	require.NoError(t, m.WritePrometheus(&b))
+	out := b.String()
+
+	// pending time depends on the clock, everything else is exact
+	float := `[0-9.e+-]+`
+	want := strings.Join([]string{
+		`# HELP neva_port_messages_sent_total Number of messages sent from the port\.`,
+		`# TYPE neva_port_messages_sent_total counter`,
+		`neva_port_messages_sent_total\{path="in",port="start",idx="0"\} 1`,
+		`neva_port_messages_sent_total\{path="println/in",port="data",idx="0"\} 0`,
+		`# HELP neva_port_messages_received_total Number of messages received by the port\.`,
+		`# TYPE neva_port_messages_received_total counter`,
+		`neva_port_messages_received_total\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_messages_received_total\{path="println/in",port="data",idx="0"\} 1`,
+		`# HELP neva_port_queue_length .*`,
+		`# TYPE neva_port_queue_length gauge`,
+		`neva_port_queue_length\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_queue_length\{path="println/in",port="data",idx="0"\} 0`,
+		`# HELP neva_port_queue_length_max .*`,
+		`# TYPE neva_port_queue_length_max gauge`,
+		`neva_port_queue_length_max\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_queue_length_max\{path="println/in",port="data",idx="0"\} 0`,
+		`# HELP neva_port_buffer_size .*`,
+		`# TYPE neva_port_buffer_size gauge`,
+		`neva_port_buffer_size\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_buffer_size\{path="println/in",port="data",idx="0"\} 0`,
+		`# HELP neva_port_pending_seconds .*`,
+		`# TYPE neva_port_pending_seconds summary`,
+		`neva_port_pending_seconds_sum\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_pending_seconds_count\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_pending_seconds_sum\{path="println/in",port="data",idx="0"\} ` + float,
+		`neva_port_pending_seconds_count\{path="println/in",port="data",idx="0"\} 1`,
+		`# HELP neva_port_pending_max_seconds .*`,
+		`# TYPE neva_port_pending_max_seconds gauge`,
+		`neva_port_pending_max_seconds\{path="in",port="start",idx="0"\} 0`,
+		`neva_port_pending_max_seconds\{path="println/in",port="data",idx="0"\} ` + float,
+	}, "\n")
+
+	require.Regexp(t, regexp.MustCompile(`^`+want+`\n$`), out)
+}
@@ -0,0 +1,100 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/nevalang/neva/internal/compiler/backend/golang"
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/nevalang/neva/internal/runtime/ir"
+)
+
+var ErrNativeFuncsObserved = errors.New(
+	"program with native funcs runs as separate executable, it can't be debugged, traced or watched",
+)
+
+// runNative runs program that uses native funcs of its modules.
+// Interpreter can't load their Go code into its own process, so it builds executable
+// with Go toolchain the same way native backend does and runs it.
+// Executable handles signals by itself, interpreter only forwards them.
+func (i Interpreter) runNative(ctx context.Context, prog *ir.Program) error {
+	if i.observed && i.seed == nil {
+		return ErrNativeFuncsObserved
+	}
+
+	dir, err := os.MkdirTemp("", "neva-native-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := golang.NewBackend().Emit(dir, prog); err != nil {
+		return err
+	}
+
+	exe := filepath.Join(dir, "program")
+	build := exec.CommandContext(ctx, "go", "build", "-o", exe, ".")
+	build.Dir = dir
+	build.Stdout = os.Stderr
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("build native funcs: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, exe, runtime.Args(ctx)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if i.shutdown.DrainTimeout > 0 {
+		cmd.Env = append(cmd.Env, "NEVA_DRAIN_TIMEOUT="+i.shutdown.DrainTimeout.String())
+	}
+	if i.seed != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("NEVA_SEED=%d", *i.seed))
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if len(i.shutdown.Signals) > 0 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, i.shutdown.Signals...)
+		defer signal.Stop(signals)
+
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case sig := <-signals:
+					_ = cmd.Process.Signal(sig)
+				}
+			}
+		}()
+	}
+
+	return nativeExitError(cmd.Wait())
+}
+
+// nativeExitError turns error of finished executable into the one interpreter exits with.
+// Executable has already printed its error, only exit code matters.
+func nativeExitError(err error) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return &runtime.ExitError{Code: 128 + int(status.Signal())}
+	}
+	return &runtime.ExitError{Code: exitErr.ExitCode()}
+}
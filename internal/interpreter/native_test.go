@@ -0,0 +1,26 @@
+package interpreter
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeExitError(t *testing.T) {
+	t.Run("exit code", func(t *testing.T) {
+		err := nativeExitError(exec.Command("sh", "-c", "exit 3").Run())
+		require.Equal(t, &runtime.ExitError{Code: 3}, err)
+	})
+
+	t.Run("killed by signal", func(t *testing.T) {
+		err := nativeExitError(exec.Command("sh", "-c", "kill -TERM $$").Run())
+		require.Equal(t, &runtime.ExitError{Code: 128 + int(syscall.SIGTERM)}, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, nativeExitError(exec.Command("true").Run()))
+	})
+}
@@ -0,0 +1,130 @@
+package interpreter
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// Replayer is an event listener that forces messages to reach their receivers in the recorded order.
+// For every receiver port it knows the sequence of senders it got messages from
+// and holds pending messages from other senders until it's their turn.
+// If program does something that was not recorded or the awaited message doesn't come in time,
+// replayer lets the message through and remembers the divergence.
+type Replayer struct {
+	mu      sync.Mutex
+	queues  map[runtime.PortAddr][]runtime.PortAddr // receiver -> senders in the order of receiving
+	changed chan struct{}                           // closed and replaced every time some queue moves
+	timeout time.Duration
+
+	divergences []string
+}
+
+func (r *Replayer) Send(event runtime.Event, msg runtime.Msg) runtime.Msg {
+	switch event.Type {
+	case runtime.MessagePendingEvent:
+		r.wait(event.MessagePending.Meta.SenderPortAddr, event.MessagePending.ReceiverPortAddr)
+	case runtime.MessageReceivedEvent:
+		r.advance(event.MessageReceived.Meta.SenderPortAddr, event.MessageReceived.ReceiverPortAddr)
+	}
+	return msg
+}
+
+// wait blocks until it's sender's turn to deliver message to receiver.
+func (r *Replayer) wait(sender, receiver runtime.PortAddr) {
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	for {
+		r.mu.Lock()
+
+		queue := r.queues[receiver]
+		if len(queue) == 0 || queue[0] == sender {
+			r.mu.Unlock()
+			return
+		}
+
+		if !slices.Contains(queue, sender) {
+			r.diverge("unexpected message %v -> %v, expected one from %v", sender, receiver, queue[0])
+			r.mu.Unlock()
+			return
+		}
+
+		changed := r.changed
+		r.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			r.mu.Lock()
+			r.diverge("timeout waiting for %v -> %v, delivering %v -> %v first", queue[0], receiver, sender, receiver)
+			r.mu.Unlock()
+			return
+		}
+	}
+}
+
+// advance removes the first occurrence of sender from receiver's queue and wakes up waiting deliveries.
+func (r *Replayer) advance(sender, receiver runtime.PortAddr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue := r.queues[receiver]
+	idx := slices.Index(queue, sender)
+	if idx == -1 {
+		return
+	}
+
+	r.queues[receiver] = slices.Delete(queue, idx, idx+1)
+
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+func (r *Replayer) diverge(format string, args ...any) {
+	r.divergences = append(r.divergences, fmt.Sprintf(format, args...))
+}
+
+// Divergences returns descriptions of all the places where execution didn't follow the trace.
+func (r *Replayer) Divergences() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.divergences)
+}
+
+// NewReplayer creates replayer from recorded trace.
+// Timeout limits how long single message can wait for its turn.
+func NewReplayer(records []TraceRecord, timeout time.Duration) (*Replayer, error) {
+	queues := map[runtime.PortAddr][]runtime.PortAddr{}
+
+	for _, record := range records {
+		if record.Event != runtime.MessageReceivedEvent.String() {
+			continue
+		}
+
+		if len(record.Receivers) != 1 {
+			return nil, fmt.Errorf("record %v: received event must have exactly one receiver", record.Seq)
+		}
+
+		sender, err := ParsePortAddr(record.Sender)
+		if err != nil {
+			return nil, fmt.Errorf("record %v: %w", record.Seq, err)
+		}
+
+		receiver, err := ParsePortAddr(record.Receivers[0])
+		if err != nil {
+			return nil, fmt.Errorf("record %v: %w", record.Seq, err)
+		}
+
+		queues[receiver] = append(queues[receiver], sender)
+	}
+
+	return &Replayer{
+		queues:  queues,
+		changed: make(chan struct{}),
+		timeout: timeout,
+	}, nil
+}
@@ -0,0 +1,296 @@
+package interpreter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// TraceFormat defines how tracer writes recorded events.
+type TraceFormat string
+
+const (
+	// TraceFormatJSONL writes header line followed by one TraceRecord per line as events happen.
+	TraceFormatJSONL TraceFormat = "jsonl"
+	// TraceFormatChrome writes chrome "trace_event" JSON that can be opened in chrome://tracing or Perfetto.
+	// Events are buffered in memory and written on Close.
+	TraceFormatChrome TraceFormat = "chrome"
+)
+
+// TraceHeader is the first line of JSONL trace.
+type TraceHeader struct {
+	Program string    `json:"program"`
+	Start   time.Time `json:"start"`
+}
+
+// TraceRecord describes single message event.
+// Time is monotonic number of nanoseconds passed since the tracer was created.
+type TraceRecord struct {
+	Seq       uint64          `json:"seq"`
+	Time      int64           `json:"ts"`
+	Event     string          `json:"event"`
+	Sender    string          `json:"sender"`
+	Receivers []string        `json:"receivers"`
+	Msg       json.RawMessage `json:"msg"`
+	Source    string          `json:"source,omitempty"` // Where the sender node is declared
+}
+
+// Tracer is an event listener that records every message event. It never modifies messages.
+// Close must be called after the program is finished to flush the trace.
+type Tracer struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	format TraceFormat
+	start  time.Time
+	seq    uint64
+	err    error
+
+	// chrome format only
+	chromeEvents []map[string]any
+	lanes        map[string]int
+	pending      map[[2]runtime.PortAddr][]uint64 // pending->received pairs are rendered as async slices
+}
+
+func (t *Tracer) Send(event runtime.Event, msg runtime.Msg) runtime.Msg {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts := time.Since(t.start) // measured under lock so timestamps grow with sequence numbers
+
+	if t.err != nil {
+		return msg
+	}
+
+	t.seq++
+
+	record := TraceRecord{
+		Seq:   t.seq,
+		Time:  ts.Nanoseconds(),
+		Event: event.Type.String(),
+		Msg:   marshalTraceMsg(msg),
+	}
+
+	switch event.Type {
+	case runtime.MessageSentEvent:
+		record.Sender = event.MessageSent.SenderPortAddr.String()
+		record.Source = event.MessageSent.SenderLocation.String()
+		record.Receivers = make([]string, 0, len(event.MessageSent.ReceiverPortAddrs))
+		for addr := range event.MessageSent.ReceiverPortAddrs {
+			record.Receivers = append(record.Receivers, addr.String())
+		}
+	case runtime.MessagePendingEvent:
+		record.Sender = event.MessagePending.Meta.SenderPortAddr.String()
+		record.Source = event.MessagePending.Meta.SenderLocation.String()
+		record.Receivers = []string{event.MessagePending.ReceiverPortAddr.String()}
+	case runtime.MessageReceivedEvent:
+		record.Sender = event.MessageReceived.Meta.SenderPortAddr.String()
+		record.Source = event.MessageReceived.Meta.SenderLocation.String()
+		record.Receivers = []string{event.MessageReceived.ReceiverPortAddr.String()}
+	}
+
+	if t.format == TraceFormatChrome {
+		t.appendChromeEvents(event, record)
+		return msg
+	}
+
+	t.writeLine(record)
+
+	return msg
+}
+
+func (t *Tracer) writeLine(v any) {
+	bb, err := json.Marshal(v)
+	if err != nil {
+		t.err = err
+		return
+	}
+	if _, err := t.w.Write(append(bb, '\n')); err != nil {
+		t.err = err
+	}
+}
+
+func (t *Tracer) appendChromeEvents(event runtime.Event, record TraceRecord) {
+	args := map[string]any{
+		"seq":       record.Seq,
+		"sender":    record.Sender,
+		"receivers": record.Receivers,
+		"msg":       record.Msg,
+	}
+
+	if record.Source != "" {
+		args["source"] = record.Source
+	}
+
+	var (
+		lane    string
+		laneLoc runtime.SourceLocation
+		pair    [2]runtime.PortAddr
+	)
+	switch event.Type {
+	case runtime.MessageSentEvent:
+		lane = event.MessageSent.SenderPortAddr.NodePath()
+		laneLoc = event.MessageSent.SenderLocation
+	case runtime.MessagePendingEvent:
+		lane = event.MessagePending.ReceiverPortAddr.NodePath()
+		laneLoc = event.MessagePending.Meta.ReceiverLocation(event.MessagePending.ReceiverPortAddr)
+		pair = [2]runtime.PortAddr{event.MessagePending.Meta.SenderPortAddr, event.MessagePending.ReceiverPortAddr}
+	case runtime.MessageReceivedEvent:
+		lane = event.MessageReceived.ReceiverPortAddr.NodePath()
+		laneLoc = event.MessageReceived.Meta.ReceiverLocation(event.MessageReceived.ReceiverPortAddr)
+		pair = [2]runtime.PortAddr{event.MessageReceived.Meta.SenderPortAddr, event.MessageReceived.ReceiverPortAddr}
+	}
+
+	tid, ok := t.lanes[lane]
+	if !ok {
+		tid = len(t.lanes) + 1
+		t.lanes[lane] = tid
+		laneName := lane
+		if laneLoc.File != "" {
+			laneName = fmt.Sprintf("%v (%v)", lane, laneLoc)
+		}
+		t.chromeEvents = append(t.chromeEvents, map[string]any{
+			"name": "thread_name",
+			"ph":   "M",
+			"pid":  1,
+			"tid":  tid,
+			"args": map[string]any{"name": laneName},
+		})
+	}
+
+	ts := float64(record.Time) / float64(time.Microsecond)
+	name := fmt.Sprintf("%v -> %v", record.Sender, strings.Join(record.Receivers, ", "))
+
+	t.chromeEvents = append(t.chromeEvents, map[string]any{
+		"name": name,
+		"cat":  record.Event,
+		"ph":   "i",
+		"s":    "t",
+		"ts":   ts,
+		"pid":  1,
+		"tid":  tid,
+		"args": args,
+	})
+
+	// time between pending and received is the time receiver was busy
+	switch event.Type {
+	case runtime.MessagePendingEvent:
+		t.pending[pair] = append(t.pending[pair], record.Seq)
+		t.chromeEvents = append(t.chromeEvents, map[string]any{
+			"name": name,
+			"cat":  "blocked",
+			"ph":   "b",
+			"id":   record.Seq,
+			"ts":   ts,
+			"pid":  1,
+			"tid":  tid,
+		})
+	case runtime.MessageReceivedEvent:
+		ids := t.pending[pair]
+		if len(ids) == 0 {
+			return
+		}
+		t.pending[pair] = ids[1:]
+		t.chromeEvents = append(t.chromeEvents, map[string]any{
+			"name": name,
+			"cat":  "blocked",
+			"ph":   "e",
+			"id":   ids[0],
+			"ts":   ts,
+			"pid":  1,
+			"tid":  tid,
+		})
+	}
+}
+
+// Close writes buffered data. Tracer must not be used after that.
+func (t *Tracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	if t.format == TraceFormatChrome {
+		t.writeLine(map[string]any{
+			"traceEvents":     t.chromeEvents,
+			"displayTimeUnit": "ns",
+		})
+		if t.err != nil {
+			return t.err
+		}
+	}
+
+	return t.w.Flush()
+}
+
+// marshalTraceMsg serializes message based on its type, see runtime.MsgToJSONValue.
+func marshalTraceMsg(msg runtime.Msg) json.RawMessage {
+	bb, err := json.Marshal(runtime.MsgToJSONValue(msg))
+	if err != nil {
+		panic(err) // values are built from json-friendly types only
+	}
+	return bb
+}
+
+func NewTracer(w io.Writer, format TraceFormat, program string) (*Tracer, error) {
+	t := &Tracer{
+		w:       bufio.NewWriter(w),
+		format:  format,
+		start:   time.Now(),
+		lanes:   map[string]int{},
+		pending: map[[2]runtime.PortAddr][]uint64{},
+	}
+
+	switch format {
+	case TraceFormatJSONL:
+		t.writeLine(TraceHeader{
+			Program: program,
+			Start:   t.start,
+		})
+	case TraceFormatChrome:
+	default:
+		return nil, fmt.Errorf("unknown trace format: %v", format)
+	}
+
+	return t, t.err
+}
+
+// ReadTrace reads trace written in JSONL format.
+func ReadTrace(r io.Reader) (TraceHeader, []TraceRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024) // messages can be big
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return TraceHeader{}, nil, err
+		}
+		return TraceHeader{}, nil, errors.New("trace is empty")
+	}
+
+	var header TraceHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return TraceHeader{}, nil, fmt.Errorf("header: %w", err)
+	}
+	if header.Start.IsZero() {
+		return TraceHeader{}, nil, errors.New("trace must be recorded in jsonl format")
+	}
+
+	records := []TraceRecord{}
+	for scanner.Scan() {
+		var record TraceRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return TraceHeader{}, nil, fmt.Errorf("record %v: %w", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+
+	return header, records, scanner.Err()
+}
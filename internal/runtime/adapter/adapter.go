@@ -21,6 +21,16 @@ func (a Adapter) Adapt(irProg *ir.Program) (runtime.Program, error) { //nolint:f
 		}] = make(chan runtime.Msg, portInfo.BufSize)
 	}
 
+	sourceMap := make(runtime.SourceMap, len(irProg.SourceMap))
+	for path, loc := range irProg.SourceMap {
+		sourceMap[path] = runtime.SourceLocation{
+			File:      loc.File,
+			Line:      loc.Line,
+			Column:    loc.Column,
+			Component: loc.Component,
+		}
+	}
+
 	runtimeConnections := make([]runtime.Connection, 0, len(irProg.Connections))
 	for _, conn := range irProg.Connections {
 		senderPortAddr := runtime.PortAddr{
@@ -37,6 +47,8 @@ func (a Adapter) Adapt(irProg *ir.Program) (runtime.Program, error) { //nolint:f
 		meta := runtime.ConnectionMeta{
 			SenderPortAddr:    senderPortAddr,
 			ReceiverPortAddrs: make([]runtime.PortAddr, 0, len(conn.ReceiverSides)),
+			SenderLocation:    sourceMap.Lookup(senderPortAddr),
+			ReceiverLocations: make([]runtime.SourceLocation, 0, len(conn.ReceiverSides)),
 		}
 		receiverChans := make([]chan runtime.Msg, 0, len(conn.ReceiverSides))
 
@@ -53,6 +65,7 @@ func (a Adapter) Adapt(irProg *ir.Program) (runtime.Program, error) { //nolint:f
 			}
 
 			meta.ReceiverPortAddrs = append(meta.ReceiverPortAddrs, receiverPortAddr)
+			meta.ReceiverLocations = append(meta.ReceiverLocations, sourceMap.Lookup(receiverPortAddr))
 			receiverChans = append(receiverChans, receiverPortChan)
 		}
 
@@ -91,6 +104,7 @@ func (a Adapter) Adapt(irProg *ir.Program) (runtime.Program, error) { //nolint:f
 				In:  rIOIn,
 				Out: rIOOut,
 			},
+			NodePath: runtime.PortAddr{Path: f.IO.Path()}.NodePath(),
 		}
 
 		if f.Msg != nil {
@@ -108,6 +122,9 @@ func (a Adapter) Adapt(irProg *ir.Program) (runtime.Program, error) { //nolint:f
 		Ports:       runtimePorts,
 		Connections: runtimeConnections,
 		Funcs:       runtimeFuncs,
+		SourceMap:   sourceMap,
+
+		StopIsExitCode: irProg.StopIsExitCode,
 	}, nil
 }
 
@@ -0,0 +1,20 @@
+package runtime
+
+import (
+	"context"
+	"os"
+)
+
+// WithArgs returns context that makes Run pass given command-line arguments to the program.
+// Arguments must not include program name. Without it program gets arguments of the current process.
+func WithArgs(ctx context.Context, args []string) context.Context {
+	return context.WithValue(ctx, "args", args) //nolint:staticcheck // SA1029
+}
+
+// Args returns command-line arguments of the program without its name.
+func Args(ctx context.Context) []string {
+	if args, ok := ctx.Value("args").([]string); ok {
+		return args
+	}
+	return os.Args[1:]
+}
@@ -5,18 +5,19 @@ import (
 	"sync"
 )
 
-type Connector struct {
+// DefaultConnector runs every connection in its own goroutine.
+type DefaultConnector struct {
 	listener EventListener
 }
 
-func (c Connector) Connect(ctx context.Context, conns []Connection) {
+func (c DefaultConnector) Connect(ctx context.Context, conns []Connection, gate *Gate) {
 	wg := sync.WaitGroup{}
 	wg.Add(len(conns))
 
 	for i := range conns {
 		conn := conns[i]
 		go func() {
-			c.broadcast(ctx, conn)
+			c.broadcast(ctx, conn, gate)
 			wg.Done()
 		}()
 	}
@@ -24,19 +25,29 @@ func (c Connector) Connect(ctx context.Context, conns []Connection) {
 	wg.Wait()
 }
 
-func (c Connector) broadcast(ctx context.Context, conn Connection) {
+func (c DefaultConnector) broadcast(ctx context.Context, conn Connection, gate *Gate) {
 	receiversForEvent := getReceiversForEvent(conn)
+	draining := gate.Draining()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-draining:
+			draining = nil
+			if !gate.Open(conn.Sender) { // gate never opens again
+				<-ctx.Done()
+				return
+			}
 		case msg := <-conn.Sender:
+			gate.Taken(conn.Sender, conn.Receivers)
+
 			event := Event{
 				Type: MessageSentEvent,
 				MessageSent: &EventMessageSent{
 					SenderPortAddr:    conn.Meta.SenderPortAddr,
 					ReceiverPortAddrs: receiversForEvent,
+					SenderLocation:    conn.Meta.SenderLocation,
 				},
 			}
 			// distribute will send to this channel after processing first receiver
@@ -46,8 +57,10 @@ func (c Connector) broadcast(ctx context.Context, conn Connection) {
 			c.distribute(
 				ctx,
 				c.listener.Send(event, msg),
+				conn.Sender,
 				conn.Meta,
 				conn.Receivers,
+				gate,
 			)
 		}
 	}
@@ -62,11 +75,13 @@ func getReceiversForEvent(conn Connection) map[PortAddr]struct{} {
 }
 
 // distribute implements the "Queue-based Round-Robin Algorithm".
-func (c Connector) distribute(
+func (c DefaultConnector) distribute(
 	ctx context.Context,
 	msg Msg,
+	sender chan Msg,
 	meta ConnectionMeta,
 	receiverChans []chan Msg,
+	gate *Gate,
 ) {
 	i := 0
 	interceptedMsgs := make(map[PortAddr]Msg, len(receiverChans)) // we can handle same receiver multiple times
@@ -99,6 +114,8 @@ func (c Connector) distribute(
 		case <-ctx.Done():
 			return
 		case curRecv <- interceptedMsg: // receiver has accepted the message
+			gate.Delivered(sender, curRecv)
+
 			event := Event{
 				Type: MessageReceivedEvent,
 				MessageReceived: &EventMessageReceived{
@@ -124,14 +141,14 @@ func (c Connector) distribute(
 	}
 }
 
-func NewDefaultConnector() Connector {
-	return Connector{
+func NewDefaultConnector() DefaultConnector {
+	return DefaultConnector{
 		listener: EmptyListener{},
 	}
 }
 
-func NewConnector(lis EventListener) Connector {
-	return Connector{
+func NewConnector(lis EventListener) DefaultConnector {
+	return DefaultConnector{
 		listener: lis,
 	}
 }
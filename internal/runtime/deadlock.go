@@ -0,0 +1,196 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrDeadlock = errors.New("deadlock")
+
+// DeadlockDetector is an event listener and a watcher that stops the program
+// if there were no message events for the given timeout while the program is still running.
+// It must be used as a listener of the connector and as a watcher of the runtime at the same time.
+// Note that program that waits for something external (e.g. user input) for too long is also considered stuck.
+type DeadlockDetector struct {
+	timeout time.Duration
+
+	mu           sync.Mutex
+	lastActivity time.Time
+	pending      map[[2]PortAddr][]time.Time // sender, receiver -> when messages became pending
+}
+
+func (d *DeadlockDetector) Send(event Event, msg Msg) Msg {
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastActivity = now
+
+	switch event.Type {
+	case MessagePendingEvent:
+		pair := [2]PortAddr{event.MessagePending.Meta.SenderPortAddr, event.MessagePending.ReceiverPortAddr}
+		d.pending[pair] = append(d.pending[pair], now)
+	case MessageReceivedEvent:
+		pair := [2]PortAddr{event.MessageReceived.Meta.SenderPortAddr, event.MessageReceived.ReceiverPortAddr}
+		if since := d.pending[pair]; len(since) > 1 {
+			d.pending[pair] = since[1:]
+		} else {
+			delete(d.pending, pair)
+		}
+	}
+
+	return msg
+}
+
+func (d *DeadlockDetector) Watch(ctx context.Context, prog Program) error {
+	d.mu.Lock()
+	d.lastActivity = time.Now()
+	d.mu.Unlock()
+
+	ticker := time.NewTicker(max(d.timeout/4, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := d.check(now, prog); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// check returns error if program is idle for too long.
+func (d *DeadlockDetector) check(now time.Time, prog Program) *DeadlockError {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	idle := now.Sub(d.lastActivity)
+	if idle < d.timeout {
+		return nil
+	}
+
+	err := &DeadlockError{Idle: idle, SourceMap: prog.SourceMap}
+
+	for pair, since := range d.pending {
+		err.Pending = append(err.Pending, PendingMessages{
+			Sender:   pair[0],
+			Receiver: pair[1],
+			Count:    len(since),
+			Since:    now.Sub(since[0]),
+		})
+	}
+	sort.Slice(err.Pending, func(i, j int) bool {
+		if err.Pending[i].Receiver != err.Pending[j].Receiver {
+			return err.Pending[i].Receiver.String() < err.Pending[j].Receiver.String()
+		}
+		return err.Pending[i].Sender.String() < err.Pending[j].Sender.String()
+	})
+
+	for addr, ch := range prog.Ports {
+		if len(ch) == 0 {
+			continue
+		}
+		err.Buffered = append(err.Buffered, BufferedPort{
+			Port: addr,
+			Len:  len(ch),
+			Cap:  cap(ch),
+		})
+	}
+	sort.Slice(err.Buffered, func(i, j int) bool {
+		return err.Buffered[i].Port.String() < err.Buffered[j].Port.String()
+	})
+
+	return err
+}
+
+// DeadlockError describes the state of the program at the moment it was considered stuck.
+type DeadlockError struct {
+	Idle      time.Duration
+	Pending   []PendingMessages // Messages that have reached receivers that don't read them
+	Buffered  []BufferedPort    // Ports that have unread messages in their buffers
+	SourceMap SourceMap         // Used to point to nodes in source code
+}
+
+// PendingMessages are messages from the same sender that wait for the same receiver.
+type PendingMessages struct {
+	Sender, Receiver PortAddr
+	Count            int
+	Since            time.Duration // How long the first of them is waiting
+}
+
+type BufferedPort struct {
+	Port     PortAddr
+	Len, Cap int
+}
+
+func (e *DeadlockError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%v: no messages were sent or received for %v", ErrDeadlock, e.Idle.Round(time.Millisecond))
+
+	if len(e.Pending) == 0 && len(e.Buffered) == 0 {
+		b.WriteString("\nthere are no pending messages, all nodes are waiting for input")
+		return b.String()
+	}
+
+	if len(e.Pending) > 0 {
+		b.WriteString("\npending messages:")
+		for _, p := range e.Pending {
+			fmt.Fprintf(
+				&b,
+				"\n\t%v -> %v (%v -> %v): %d message(s), waiting for %v",
+				p.Sender, p.Receiver,
+				p.Sender.NodePath(), p.Receiver.NodePath(),
+				p.Count, p.Since.Round(time.Millisecond),
+			)
+		}
+
+		b.WriteString("\nbusy receivers:")
+		seen := map[PortAddr]struct{}{}
+		for _, p := range e.Pending {
+			if _, ok := seen[p.Receiver]; ok {
+				continue
+			}
+			seen[p.Receiver] = struct{}{}
+			fmt.Fprintf(&b, "\n\t%v doesn't read %v", e.node(p.Receiver), p.Receiver)
+		}
+	}
+
+	if len(e.Buffered) > 0 {
+		b.WriteString("\nbuffered messages:")
+		for _, p := range e.Buffered {
+			fmt.Fprintf(&b, "\n\t%v (%v): %d/%d", p.Port, e.node(p.Port), p.Len, p.Cap)
+		}
+	}
+
+	return b.String()
+}
+
+// node returns path of the node that port belongs to and its location in source code if it's known.
+func (e *DeadlockError) node(addr PortAddr) string {
+	loc := e.SourceMap.Lookup(addr)
+	if loc.File == "" {
+		return addr.NodePath()
+	}
+	return fmt.Sprintf("%v at %v", addr.NodePath(), loc)
+}
+
+func (e *DeadlockError) Unwrap() error {
+	return ErrDeadlock
+}
+
+func NewDeadlockDetector(timeout time.Duration) *DeadlockDetector {
+	return &DeadlockDetector{
+		timeout: timeout,
+		pending: map[[2]PortAddr][]time.Time{},
+	}
+}
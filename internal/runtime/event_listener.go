@@ -32,6 +32,7 @@ func (e Event) String() string {
 type EventMessageSent struct {
 	SenderPortAddr    PortAddr
 	ReceiverPortAddrs map[PortAddr]struct{} // We use map to work with breakpoints
+	SenderLocation    SourceLocation
 }
 
 func (e EventMessageSent) String() string {
@@ -98,3 +99,14 @@ func (e EventType) String() string {
 type EventListener interface {
 	Send(event Event, msg Msg) Msg
 }
+
+// ListenerChain passes every event through all of its listeners in order.
+// Message returned by one listener is passed to the next one.
+type ListenerChain []EventListener
+
+func (c ListenerChain) Send(event Event, msg Msg) Msg {
+	for _, l := range c {
+		msg = l.Send(event, msg)
+	}
+	return msg
+}
@@ -0,0 +1,44 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExitError is returned by Run when program has finished with non-zero exit code.
+// Exit code is either a message sent to int stop outport of Main or a code passed to Exit.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// InvalidExitCodeError is returned by Run when program tries to exit with code that process can't have.
+// Only the low 8 bits of the code reach the parent process, so e.g. 256 would look like success.
+type InvalidExitCodeError struct {
+	Code int64
+}
+
+func (e *InvalidExitCodeError) Error() string {
+	return fmt.Sprintf("invalid exit code %d: must be in range 0..255", e.Code)
+}
+
+// Exit stops the program. Run returns *ExitError if code isn't zero.
+func Exit(ctx context.Context, code int64) {
+	if exit, ok := ctx.Value("exit").(func(int64)); ok {
+		exit(code)
+	}
+}
+
+// exitError returns nil for zero code so successful programs don't have errors.
+func exitError(code int64) error {
+	switch {
+	case code == 0:
+		return nil
+	case code < 0 || code > 255:
+		return &InvalidExitCodeError{Code: code}
+	}
+	return &ExitError{Code: int(code)}
+}
@@ -0,0 +1,18 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitError(t *testing.T) {
+	require.NoError(t, exitError(0))
+	require.Equal(t, &ExitError{Code: 1}, exitError(1))
+	require.Equal(t, &ExitError{Code: 255}, exitError(255))
+
+	// out of range codes would wrap around, e.g. 256 would become 0
+	for _, code := range []int64{256, -1, 1 << 40} {
+		require.Equal(t, &InvalidExitCodeError{Code: code}, exitError(code))
+	}
+}
@@ -17,6 +17,7 @@ type FuncCreator interface {
 
 func (d FuncRunner) Run(funcCalls []FuncCall) (func(ctx context.Context), error) {
 	funcs := make([]func(context.Context), len(funcCalls))
+	paths := make([]string, len(funcCalls))
 
 	for i, call := range funcCalls {
 		creator, ok := d.registry[call.Ref]
@@ -30,6 +31,7 @@ func (d FuncRunner) Run(funcCalls []FuncCall) (func(ctx context.Context), error)
 		}
 
 		funcs[i] = handler
+		paths[i] = call.NodePath
 	}
 
 	return func(ctx context.Context) {
@@ -37,8 +39,10 @@ func (d FuncRunner) Run(funcCalls []FuncCall) (func(ctx context.Context), error)
 		wg.Add(len(funcs))
 		for i := range funcs {
 			routine := funcs[i]
+			// so Panic can tell which node has stopped the program
+			routineCtx := context.WithValue(ctx, "node", paths[i]) //nolint:staticcheck // SA1029
 			go func() {
-				routine(ctx)
+				routine(routineCtx)
 				wg.Done()
 			}()
 		}
@@ -46,6 +50,23 @@ func (d FuncRunner) Run(funcCalls []FuncCall) (func(ctx context.Context), error)
 	}, nil
 }
 
+// signalPorts returns ports that funcs pass to Signals, see SignalFunc.
+func (d FuncRunner) signalPorts(funcCalls []FuncCall) ([]chan Msg, error) {
+	var ports []chan Msg
+	for _, call := range funcCalls {
+		signalFunc, ok := d.registry[call.Ref].(SignalFunc)
+		if !ok {
+			continue
+		}
+		port, err := signalFunc.SignalPort(call.IO)
+		if err != nil {
+			return nil, fmt.Errorf("signal port: %w: %v", err, call.Ref)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
 func MustNewFuncRunner(registry map[string]FuncCreator) FuncRunner {
 	if registry == nil {
 		panic(ErrNilDeps)
@@ -0,0 +1,83 @@
+package funcs
+
+import (
+	"context"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type appendFile struct{}
+
+func (appendFile) Ports() (in, out []string) {
+	return []string{"filename", "data"}, []string{"sig", "err"}
+}
+
+func (c appendFile) Create(rio runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	filenameIn, err := rio.In.Port("filename")
+	if err != nil {
+		return nil, err
+	}
+
+	dataIn, err := rio.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := rio.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := rio.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var name, data runtime.Msg
+
+			select {
+			case <-ctx.Done():
+				return
+			case name = <-filenameIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case data = <-dataIn:
+			}
+
+			if err := c.append(name.Str(), data.Str()); err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sigOut <- nil:
+			}
+		}
+	}, nil
+}
+
+func (appendFile) append(name, data string) error {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
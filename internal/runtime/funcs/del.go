@@ -8,6 +8,10 @@ import (
 
 type del struct{}
 
+func (del) Ports() (in, out []string) {
+	return []string{"msg"}, nil
+}
+
 func (d del) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	outport, err := io.In.Port("msg")
 	if err != nil {
@@ -9,6 +9,10 @@ import (
 
 type readStructField struct{}
 
+func (readStructField) Ports() (in, out []string) {
+	return []string{"msg"}, []string{"msg"}
+}
+
 func (s readStructField) Create(io runtime.FuncIO, fieldPathMsg runtime.Msg) (func(ctx context.Context), error) {
 	fieldPath := fieldPathMsg.List()
 	if len(fieldPath) == 0 {
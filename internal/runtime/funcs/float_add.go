@@ -0,0 +1,54 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type floatAdd struct{}
+
+func (floatAdd) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
+func (floatAdd) Create(
+	io runtime.FuncIO,
+	_ runtime.Msg,
+) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var acc float64 = 0
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			acc += item["data"].Float()
+
+			if item["last"].Bool() {
+				select {
+				case <-ctx.Done():
+					return
+				case resOut <- runtime.NewFloatMsg(acc):
+					acc = 0 // reset
+					continue
+				}
+			}
+		}
+	}, nil
+}
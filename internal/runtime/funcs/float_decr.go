@@ -0,0 +1,43 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type floatDecr struct{}
+
+func (floatDecr) Ports() (in, out []string) {
+	return []string{"n"}, []string{"n"}
+}
+
+func (i floatDecr) Create(io runtime.FuncIO, _ runtime.Msg) (func(context.Context), error) {
+	nIn, err := io.In.Port("n")
+	if err != nil {
+		return nil, err
+	}
+
+	nOut, err := io.Out.Port("n")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var n runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n = <-nIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case nOut <- runtime.NewFloatMsg(n.Float() - 1):
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,69 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type parseFloat struct{}
+
+func (parseFloat) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res", "err"}
+}
+
+func (p parseFloat) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var str runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case str = <-dataIn:
+			}
+
+			parsedNum, err := parseFloatMsg(str.Str())
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+				}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- parsedNum:
+			}
+		}
+	}, nil
+}
+
+func parseFloatMsg(str string) (runtime.Msg, error) {
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil, errors.New(strings.TrimPrefix(err.Error(), "strconv.ParseFloat: "))
+	}
+	return runtime.NewFloatMsg(v), nil
+}
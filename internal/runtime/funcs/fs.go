@@ -0,0 +1,59 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// fsPathOp is a filesystem operation on a single path like os.Remove.
+type fsPathOp struct {
+	op func(path string) error
+}
+
+func (fsPathOp) Ports() (in, out []string) {
+	return []string{"path"}, []string{"sig", "err"}
+}
+
+func (c fsPathOp) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	pathIn, err := io.In.Port("path")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := io.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var path runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case path = <-pathIn:
+			}
+
+			if err := c.op(path.Str()); err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sigOut <- nil:
+			}
+		}
+	}, nil
+}
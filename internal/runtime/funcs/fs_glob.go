@@ -0,0 +1,63 @@
+package funcs
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type fsGlob struct{}
+
+func (fsGlob) Ports() (in, out []string) {
+	return []string{"pattern"}, []string{"res", "err"}
+}
+
+func (fsGlob) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	patternIn, err := io.In.Port("pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var pattern runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case pattern = <-patternIn:
+			}
+
+			matches, err := filepath.Glob(pattern.Str())
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			list := make([]runtime.Msg, len(matches))
+			for i, match := range matches {
+				list[i] = runtime.NewStrMsg(match)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewListMsg(list...):
+			}
+		}
+	}, nil
+}
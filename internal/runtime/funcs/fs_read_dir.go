@@ -0,0 +1,69 @@
+package funcs
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type fsReadDir struct{}
+
+func (fsReadDir) Ports() (in, out []string) {
+	return []string{"path"}, []string{"seq", "err"}
+}
+
+func (fsReadDir) Create(rio runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	pathIn, err := rio.In.Port("path")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := rio.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := rio.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var path runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case path = <-pathIn:
+			}
+
+			entries, err := os.ReadDir(path.Str())
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			// entries are sorted by name
+			idx := 0
+			if err := sendStream(ctx, seqOut, func() (runtime.Msg, error) {
+				if idx == len(entries) {
+					return nil, io.EOF
+				}
+				entry := entries[idx]
+				idx++
+				return runtime.NewMapMsg(map[string]runtime.Msg{
+					"name":  runtime.NewStrMsg(entry.Name()),
+					"isDir": runtime.NewBoolMsg(entry.IsDir()),
+				}), nil
+			}); err != nil {
+				return // only context can stop the stream
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,69 @@
+package funcs
+
+import (
+	"context"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type fsRename struct{}
+
+func (fsRename) Ports() (in, out []string) {
+	return []string{"from", "to"}, []string{"sig", "err"}
+}
+
+func (fsRename) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	fromIn, err := io.In.Port("from")
+	if err != nil {
+		return nil, err
+	}
+
+	toIn, err := io.In.Port("to")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := io.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var from, to runtime.Msg
+
+			select {
+			case <-ctx.Done():
+				return
+			case from = <-fromIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case to = <-toIn:
+			}
+
+			if err := os.Rename(from.Str(), to.Str()); err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sigOut <- nil:
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,70 @@
+package funcs
+
+import (
+	"context"
+	"io/fs"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type fsStat struct{}
+
+func (fsStat) Ports() (in, out []string) {
+	return []string{"path"}, []string{"info", "err"}
+}
+
+func (fsStat) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	pathIn, err := io.In.Port("path")
+	if err != nil {
+		return nil, err
+	}
+
+	infoOut, err := io.Out.Port("info")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var path runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case path = <-pathIn:
+			}
+
+			info, err := os.Stat(path.Str())
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case infoOut <- fileInfoMsg(info):
+			}
+		}
+	}, nil
+}
+
+// fileInfoMsg builds message of FileInfo type from std/fs package.
+func fileInfoMsg(info fs.FileInfo) runtime.Msg {
+	return runtime.NewMapMsg(map[string]runtime.Msg{
+		"name":    runtime.NewStrMsg(info.Name()),
+		"size":    runtime.NewIntMsg(info.Size()),
+		"mode":    runtime.NewIntMsg(int64(info.Mode().Perm())),
+		"modTime": runtime.NewIntMsg(info.ModTime().Unix()),
+		"isDir":   runtime.NewBoolMsg(info.IsDir()),
+	})
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	goio "io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/nevalang/neva/internal/runtime"
 )
 
 type httpGet struct{}
 
+func (httpGet) Ports() (in, out []string) {
+	return []string{"url"}, []string{"resp", "err"}
+}
+
 func (httpGet) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	urlIn, err := io.In.Port("url")
 	if err != nil {
@@ -35,36 +41,148 @@ func (httpGet) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Contex
 			case <-ctx.Done():
 				return
 			}
-			resp, err := http.Get(u)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 			if err != nil {
 				select {
-				case errOut <- runtime.NewMapMsg(map[string]runtime.Msg{
-					"text": runtime.NewStrMsg(err.Error()),
-				}):
+				case errOut <- errorFromString(err.Error()):
 					continue
 				case <-ctx.Done():
 					return
 				}
 			}
-			body, err := goio.ReadAll(resp.Body)
+
+			resp, err := doHTTPRequest(req)
 			if err != nil {
 				select {
-				case errOut <- runtime.NewMapMsg(map[string]runtime.Msg{
-					"text": runtime.NewStrMsg(err.Error()),
-				}):
+				case errOut <- errorFromString(err.Error()):
 					continue
 				case <-ctx.Done():
 					return
 				}
 			}
+
 			select {
-			case respOut <- runtime.NewMapMsg(map[string]runtime.Msg{
-				"statusCode": runtime.NewIntMsg(int64(resp.StatusCode)),
-				"body":       runtime.NewStrMsg(string(body)),
-			}):
+			case respOut <- resp:
 			case <-ctx.Done():
 				return
 			}
 		}
 	}, nil
 }
+
+type httpDo struct{}
+
+func (httpDo) Ports() (in, out []string) {
+	return []string{"req"}, []string{"resp", "err"}
+}
+
+func (httpDo) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	reqIn, err := io.In.Port("req")
+	if err != nil {
+		return nil, err
+	}
+
+	respOut, err := io.Out.Port("resp")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var reqMsg runtime.Msg
+			select {
+			case reqMsg = <-reqIn:
+			case <-ctx.Done():
+				return
+			}
+
+			resp, err := httpDoMsg(ctx, reqMsg)
+			if err != nil {
+				select {
+				case errOut <- errorFromString(err.Error()):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case respOut <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, nil
+}
+
+// httpDoMsg sends request described by Request struct from std/http.
+// Every field except url is optional, zero timeout means no timeout.
+func httpDoMsg(ctx context.Context, reqMsg runtime.Msg) (runtime.Msg, error) {
+	fields := reqMsg.Map()
+
+	method := http.MethodGet
+	if m := fields["method"]; m != nil && m.Str() != "" {
+		method = m.Str()
+	}
+
+	var body goio.Reader
+	if b := fields["body"]; b != nil && b.Str() != "" {
+		body = strings.NewReader(b.Str())
+	}
+
+	if t := fields["timeout"]; t != nil && t.Int() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(t.Int()))
+		defer cancel()
+	}
+
+	var url string
+	if u := fields["url"]; u != nil {
+		url = u.Str()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if h := fields["headers"]; h != nil {
+		for name, value := range h.Map() {
+			req.Header.Set(name, value.Str())
+		}
+	}
+
+	return doHTTPRequest(req)
+}
+
+// doHTTPRequest sends request and builds Response struct from std/http.
+// Multiple values of the same response header are joined with comma.
+func doHTTPRequest(req *http.Request) (runtime.Msg, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := goio.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]runtime.Msg, len(resp.Header))
+	for name, values := range resp.Header {
+		headers[name] = runtime.NewStrMsg(strings.Join(values, ", "))
+	}
+
+	return runtime.NewMapMsg(map[string]runtime.Msg{
+		"statusCode": runtime.NewIntMsg(int64(resp.StatusCode)),
+		"headers":    runtime.NewMapMsg(headers),
+		"body":       runtime.NewStrMsg(string(body)),
+	}), nil
+}
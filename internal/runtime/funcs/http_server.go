@@ -0,0 +1,282 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	goio "io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// httpPending connects requests sent by Serve with responses received by Respond.
+// Serve and Respond are different nodes so they can only share state at package level.
+var httpPending = struct {
+	mu     sync.Mutex
+	lastID atomic.Int64
+	byID   map[int64]httpPendingRequest
+}{
+	byID: map[int64]httpPendingRequest{},
+}
+
+var errHTTPClientGone = errors.New("client closed connection before response was written")
+
+type httpPendingRequest struct {
+	resp    chan runtime.Msg // Respond sends ServerResponse here
+	written chan error       // Serve reports here when response is written
+}
+
+func addHTTPPending() (int64, httpPendingRequest) {
+	id := httpPending.lastID.Add(1)
+	p := httpPendingRequest{
+		resp:    make(chan runtime.Msg, 1),
+		written: make(chan error, 1),
+	}
+
+	httpPending.mu.Lock()
+	httpPending.byID[id] = p
+	httpPending.mu.Unlock()
+
+	return id, p
+}
+
+// takeHTTPPending removes request so it can be responded only once.
+func takeHTTPPending(id int64) (httpPendingRequest, bool) {
+	httpPending.mu.Lock()
+	defer httpPending.mu.Unlock()
+
+	p, ok := httpPending.byID[id]
+	delete(httpPending.byID, id)
+	return p, ok
+}
+
+type httpServe struct{}
+
+func (httpServe) Ports() (in, out []string) {
+	return []string{"addr"}, []string{"req", "err"}
+}
+
+func (httpServe) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	addrIn, err := io.In.Port("addr")
+	if err != nil {
+		return nil, err
+	}
+
+	reqOut, err := io.Out.Port("req")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		for {
+			var addr string
+			select {
+			case m := <-addrIn:
+				addr = m.Str()
+			case <-ctx.Done():
+				return
+			}
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				select {
+				case errOut <- errorFromString(err.Error()):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			srv := &http.Server{Handler: httpServeHandler(ctx, reqOut)}
+
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				<-ctx.Done()
+				srv.Close()
+			}()
+			go func() {
+				defer wg.Done()
+				err := srv.Serve(ln)
+				if errors.Is(err, http.ErrServerClosed) {
+					return
+				}
+				select {
+				case errOut <- errorFromString(err.Error()):
+				case <-ctx.Done():
+				}
+			}()
+		}
+	}, nil
+}
+
+// httpServeHandler sends every request to reqOut and waits until Respond sends response with the same id.
+// If the program or the client stops before that, request is forgotten.
+func httpServeHandler(ctx context.Context, reqOut chan<- runtime.Msg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := goio.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id, pending := addHTTPPending()
+		defer takeHTTPPending(id)
+
+		select {
+		case reqOut <- serverRequestMsg(id, r, body):
+		case <-r.Context().Done():
+			return
+		case <-ctx.Done():
+			http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+
+		// written is buffered, Respond reads it only if it took the request before we returned
+		select {
+		case resp := <-pending.resp:
+			pending.written <- writeServerResponse(w, resp)
+		case <-r.Context().Done():
+			pending.written <- errHTTPClientGone
+		case <-ctx.Done():
+			http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+			pending.written <- ctx.Err()
+		}
+	}
+}
+
+func serverRequestMsg(id int64, r *http.Request, body []byte) runtime.Msg {
+	headers := make(map[string]runtime.Msg, len(r.Header))
+	for name, values := range r.Header {
+		headers[name] = runtime.NewStrMsg(strings.Join(values, ", "))
+	}
+
+	query := make(map[string]runtime.Msg, len(r.URL.Query()))
+	for name, values := range r.URL.Query() {
+		query[name] = runtime.NewStrMsg(strings.Join(values, ", "))
+	}
+
+	return runtime.NewMapMsg(map[string]runtime.Msg{
+		"id":      runtime.NewIntMsg(id),
+		"method":  runtime.NewStrMsg(r.Method),
+		"path":    runtime.NewStrMsg(r.URL.Path),
+		"query":   runtime.NewMapMsg(query),
+		"headers": runtime.NewMapMsg(headers),
+		"body":    runtime.NewStrMsg(string(body)),
+	})
+}
+
+// writeServerResponse writes ServerResponse struct from std/http and flushes it,
+// so it reaches the client even if the program stops right after.
+// Zero status code means 200.
+func writeServerResponse(w http.ResponseWriter, resp runtime.Msg) error {
+	fields := resp.Map()
+
+	if h := fields["headers"]; h != nil {
+		for name, value := range h.Map() {
+			w.Header().Set(name, value.Str())
+		}
+	}
+
+	status := http.StatusOK
+	if s := fields["statusCode"]; s != nil && s.Int() != 0 {
+		status = int(s.Int())
+	}
+	w.WriteHeader(status)
+
+	if b := fields["body"]; b != nil {
+		if _, err := goio.WriteString(w, b.Str()); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+type httpRespond struct{}
+
+func (httpRespond) Ports() (in, out []string) {
+	return []string{"resp"}, []string{"sig", "err"}
+}
+
+func (httpRespond) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	respIn, err := io.In.Port("resp")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := io.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var resp runtime.Msg
+			select {
+			case resp = <-respIn:
+			case <-ctx.Done():
+				return
+			}
+
+			if err := respondHTTP(ctx, resp); err != nil {
+				select {
+				case errOut <- errorFromString(err.Error()):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case sigOut <- nil:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, nil
+}
+
+// respondHTTP passes response to the request it belongs to and waits until it's written.
+func respondHTTP(ctx context.Context, resp runtime.Msg) error {
+	var id int64
+	if m := resp.Map()["id"]; m != nil {
+		id = m.Int()
+	}
+
+	pending, ok := takeHTTPPending(id)
+	if !ok {
+		return fmt.Errorf("no pending request with id %d", id)
+	}
+
+	pending.resp <- resp
+
+	select {
+	case err := <-pending.written:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
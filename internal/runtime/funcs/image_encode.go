@@ -10,6 +10,10 @@ import (
 
 type imageEncode struct{}
 
+func (imageEncode) Ports() (in, out []string) {
+	return []string{"img"}, []string{"data", "err"}
+}
+
 func (imageEncode) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	in, err := io.In.Port("img")
 	if err != nil {
@@ -9,6 +9,10 @@ import (
 
 type imageNew struct{}
 
+func (imageNew) Ports() (in, out []string) {
+	return []string{"pixels"}, []string{"img", "err"}
+}
+
 func (imageNew) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	pixelsIn, err := io.In.Port("pixels")
 	if err != nil {
@@ -8,6 +8,10 @@ import (
 
 type index struct{}
 
+func (index) Ports() (in, out []string) {
+	return []string{"data", "idx"}, []string{"res", "err"}
+}
+
 func (p index) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	listIn, err := io.In.Port("data")
 	if err != nil {
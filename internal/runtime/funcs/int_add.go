@@ -8,6 +8,10 @@ import (
 
 type intAdd struct{}
 
+func (intAdd) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
 func (intAdd) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
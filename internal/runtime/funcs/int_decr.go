@@ -8,6 +8,10 @@ import (
 
 type intDecr struct{}
 
+func (intDecr) Ports() (in, out []string) {
+	return []string{"n"}, []string{"n"}
+}
+
 func (i intDecr) Create(io runtime.FuncIO, _ runtime.Msg) (func(context.Context), error) {
 	nIn, err := io.In.Port("n")
 	if err != nil {
@@ -8,6 +8,10 @@ import (
 
 type intMul struct{}
 
+func (intMul) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
 func (intMul) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	seqIn, err := io.In.Port("seq")
 	if err != nil {
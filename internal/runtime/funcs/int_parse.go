@@ -11,6 +11,10 @@ import (
 
 type parseInt struct{}
 
+func (parseInt) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res", "err"}
+}
+
 func (p parseInt) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
@@ -8,6 +8,10 @@ import (
 
 type intSub struct{}
 
+func (intSub) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
 func (intSub) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	seqIn, err := io.In.Port("seq")
 	if err != nil {
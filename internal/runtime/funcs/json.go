@@ -0,0 +1,291 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type jsonMarshal struct{}
+
+func (jsonMarshal) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res", "err"}
+}
+
+func (jsonMarshal) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var data runtime.Msg
+			select {
+			case data = <-dataIn:
+			case <-ctx.Done():
+				return
+			}
+
+			// messages implement json.Marshaler but maps are formatted for printing
+			b, err := json.Marshal(runtime.MsgToJSONValue(data))
+			if err != nil {
+				select {
+				case errOut <- errorFromString(err.Error()):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case resOut <- runtime.NewStrMsg(string(b)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, nil
+}
+
+type jsonUnmarshal struct{}
+
+func (jsonUnmarshal) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res", "err"}
+}
+
+// Create receives description of the type argument from compiler, see #typeinfo directive.
+func (jsonUnmarshal) Create(io runtime.FuncIO, typeInfo runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var data runtime.Msg
+			select {
+			case data = <-dataIn:
+			case <-ctx.Done():
+				return
+			}
+
+			res, err := jsonUnmarshalMsg(data.Str(), typeInfo)
+			if err != nil {
+				select {
+				case errOut <- errorFromString(err.Error()):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case resOut <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, nil
+}
+
+func jsonUnmarshalMsg(data string, typeInfo runtime.Msg) (runtime.Msg, error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber() // so we can tell ints from floats
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+
+	if _, err := dec.Token(); err == nil {
+		return nil, errors.New("json: unexpected data after top-level value")
+	}
+
+	return msgFromJSON(v, typeInfo, "$")
+}
+
+// msgFromJSON converts decoded value to message of the type described by typeInfo.
+// Path is used in errors to tell where the value is, e.g. $.users[0].name.
+func msgFromJSON(v any, typeInfo runtime.Msg, path string) (runtime.Msg, error) { //nolint:funlen
+	kind := "any"
+	if typeInfo != nil {
+		kind = typeInfo.Map()["kind"].Str()
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("json: %s: expected %s, got %s", path, kind, jsonKind(v))
+	}
+
+	switch kind {
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, mismatch()
+		}
+		return runtime.NewBoolMsg(b), nil
+	case "int":
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, mismatch()
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("json: %s: expected int, got %s", path, n)
+		}
+		return runtime.NewIntMsg(i), nil
+	case "float":
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, mismatch()
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("json: %s: %w", path, err)
+		}
+		return runtime.NewFloatMsg(f), nil
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, mismatch()
+		}
+		return runtime.NewStrMsg(s), nil
+	case "maybe":
+		if v == nil {
+			return nil, nil
+		}
+		return msgFromJSON(v, typeInfo.Map()["elem"], path)
+	case "list":
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, mismatch()
+		}
+		list := make([]runtime.Msg, len(arr))
+		for i, el := range arr {
+			msg, err := msgFromJSON(el, typeInfo.Map()["elem"], path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+			list[i] = msg
+		}
+		return runtime.NewListMsg(list...), nil
+	case "map":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch()
+		}
+		m := make(map[string]runtime.Msg, len(obj))
+		for k, el := range obj {
+			msg, err := msgFromJSON(el, typeInfo.Map()["elem"], path+"."+k)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = msg
+		}
+		return runtime.NewMapMsg(m), nil
+	case "struct":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch()
+		}
+		// unknown fields are ignored, missing ones are only allowed for maybe
+		fields := typeInfo.Map()["fields"].Map()
+		m := make(map[string]runtime.Msg, len(fields))
+		for name, fieldInfo := range fields {
+			el, ok := obj[name]
+			if !ok && fieldInfo.Map()["kind"].Str() != "maybe" {
+				return nil, fmt.Errorf("json: %s: missing field %s", path, name)
+			}
+			msg, err := msgFromJSON(el, fieldInfo, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			m[name] = msg
+		}
+		return runtime.NewMapMsg(m), nil
+	case "enum":
+		// enums are ints at runtime, so both member index and name are accepted
+		members := typeInfo.Map()["enum"].List()
+		switch v := v.(type) {
+		case json.Number:
+			i, err := v.Int64()
+			if err == nil && i >= 0 && i < int64(len(members)) {
+				return runtime.NewIntMsg(i), nil
+			}
+		case string:
+			for i, member := range members {
+				if member.Str() == v {
+					return runtime.NewIntMsg(int64(i)), nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("json: %s: %s is not a member of enum", path, jsonText(v))
+	case "union":
+		for _, variant := range typeInfo.Map()["union"].List() {
+			if msg, err := msgFromJSON(v, variant, path); err == nil {
+				return msg, nil
+			}
+		}
+		return nil, fmt.Errorf("json: %s: %s matches none of union types", path, jsonText(v))
+	}
+
+	msg, err := runtime.MsgFromJSONValue(v)
+	if err != nil {
+		return nil, fmt.Errorf("json: %s: %w", path, err)
+	}
+	return msg, nil
+}
+
+func jsonKind(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "int"
+		}
+		return "float"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
+
+func jsonText(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return jsonKind(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
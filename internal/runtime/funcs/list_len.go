@@ -8,6 +8,10 @@ import (
 
 type listlen struct{}
 
+func (listlen) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res"}
+}
+
 func (p listlen) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
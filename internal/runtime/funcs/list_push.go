@@ -9,6 +9,10 @@ import (
 
 type listPush struct{}
 
+func (listPush) Ports() (in, out []string) {
+	return []string{"data", "lst"}, []string{"res"}
+}
+
 func (p listPush) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
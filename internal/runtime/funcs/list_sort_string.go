@@ -9,6 +9,10 @@ import (
 
 type listSortString struct{}
 
+func (listSortString) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res"}
+}
+
 func (p listSortString) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
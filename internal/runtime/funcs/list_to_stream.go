@@ -8,6 +8,10 @@ import (
 
 type listToStream struct{}
 
+func (listToStream) Ports() (in, out []string) {
+	return []string{"data"}, []string{"seq"}
+}
+
 func (c listToStream) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
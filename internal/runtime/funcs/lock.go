@@ -8,6 +8,10 @@ import (
 
 type lock struct{}
 
+func (lock) Ports() (in, out []string) {
+	return []string{"sig", "data"}, []string{"data"}
+}
+
 func (l lock) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	sigIn, err := io.In.Port("sig")
 	if err != nil {
@@ -0,0 +1,179 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	goio "io"
+	"maps"
+	"slices"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// Map messages are shared between receivers of the same connection,
+// so funcs that modify maps always work with copies.
+
+var mapGet = binaryOp{
+	in: [2]string{"data", "key"},
+	op: func(data, key runtime.Msg) (runtime.Msg, error) {
+		v, ok := data.Map()[key.Str()]
+		if !ok {
+			return nil, fmt.Errorf("key not found: %s", key.Str())
+		}
+		return v, nil
+	},
+	fallible: true,
+}
+
+// mapLookup sends nil (none of maybe) if there's no such key.
+var mapLookup = binaryOp{
+	in: [2]string{"data", "key"},
+	op: func(data, key runtime.Msg) (runtime.Msg, error) {
+		return data.Map()[key.Str()], nil
+	},
+}
+
+var mapHas = binaryOp{
+	in: [2]string{"data", "key"},
+	op: func(data, key runtime.Msg) (runtime.Msg, error) {
+		_, ok := data.Map()[key.Str()]
+		return runtime.NewBoolMsg(ok), nil
+	},
+}
+
+var mapDelete = binaryOp{
+	in: [2]string{"data", "key"},
+	op: func(data, key runtime.Msg) (runtime.Msg, error) {
+		m := maps.Clone(data.Map())
+		delete(m, key.Str())
+		return runtime.NewMapMsg(m), nil
+	},
+}
+
+// mapMerge prefers values from the right map when both have the same key.
+var mapMerge = binaryOp{
+	in: [2]string{"left", "right"},
+	op: func(left, right runtime.Msg) (runtime.Msg, error) {
+		m := make(map[string]runtime.Msg, len(left.Map())+len(right.Map()))
+		maps.Copy(m, left.Map())
+		maps.Copy(m, right.Map())
+		return runtime.NewMapMsg(m), nil
+	},
+}
+
+type mapSet struct{}
+
+func (mapSet) Ports() (in, out []string) {
+	return []string{"data", "key", "val"}, []string{"res"}
+}
+
+func (mapSet) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	keyIn, err := io.In.Port("key")
+	if err != nil {
+		return nil, err
+	}
+
+	valIn, err := io.In.Port("val")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var data, key, val runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data = <-dataIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case key = <-keyIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case val = <-valIn:
+			}
+
+			m := make(map[string]runtime.Msg, len(data.Map())+1)
+			maps.Copy(m, data.Map())
+			m[key.Str()] = val
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewMapMsg(m):
+			}
+		}
+	}, nil
+}
+
+// mapStream streams keys or values of the map, ordered by keys.
+// Empty map produces no items, same as empty list in list_to_stream.
+type mapStream struct {
+	values bool
+}
+
+func (mapStream) Ports() (in, out []string) {
+	return []string{"data"}, []string{"seq"}
+}
+
+func (s mapStream) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var m map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-dataIn:
+				m = data.Map()
+			}
+
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+
+			i := 0
+			if err := sendStream(ctx, seqOut, func() (runtime.Msg, error) {
+				if i == len(keys) {
+					return nil, goio.EOF
+				}
+				k := keys[i]
+				i++
+				if s.values {
+					return m[k], nil
+				}
+				return runtime.NewStrMsg(k), nil
+			}); err != nil {
+				return
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,45 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type mapLen struct{}
+
+func (mapLen) Ports() (in, out []string) {
+	return []string{"data"}, []string{"res"}
+}
+
+func (p mapLen) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var data runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data = <-dataIn:
+			}
+
+			l := len(data.Map())
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewIntMsg(int64(l)):
+			}
+		}
+	}, nil
+}
@@ -9,6 +9,10 @@ import (
 
 type match struct{}
 
+func (match) Ports() (in, out []string) {
+	return []string{"data", "case"}, []string{"case", "else"}
+}
+
 func (match) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
@@ -0,0 +1,75 @@
+package funcs
+
+import (
+	"errors"
+	"math"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+var (
+	floatDiv = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			if el.Float() == 0 {
+				return nil, errDivisionByZero
+			}
+			return runtime.NewFloatMsg(acc.Float() / el.Float()), nil
+		},
+		fallible: true,
+	}
+	floatRem = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			if el.Float() == 0 {
+				return nil, errDivisionByZero
+			}
+			return runtime.NewFloatMsg(math.Mod(acc.Float(), el.Float())), nil
+		},
+		fallible: true,
+	}
+	floatMin = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewFloatMsg(math.Min(acc.Float(), el.Float())), nil
+		},
+	}
+	floatMax = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewFloatMsg(math.Max(acc.Float(), el.Float())), nil
+		},
+	}
+
+	floatNeg = unaryOp{
+		in:  "n",
+		out: "n",
+		op:  func(n runtime.Msg) runtime.Msg { return runtime.NewFloatMsg(-n.Float()) },
+	}
+	floatAbs = unaryOp{
+		in:  "n",
+		out: "n",
+		op:  func(n runtime.Msg) runtime.Msg { return runtime.NewFloatMsg(math.Abs(n.Float())) },
+	}
+
+	floatPow = binaryOp{
+		in: [2]string{"base", "exp"},
+		op: func(base, exp runtime.Msg) (runtime.Msg, error) {
+			res := math.Pow(base.Float(), exp.Float())
+			if math.IsNaN(res) {
+				return nil, errors.New("result is not a number")
+			}
+			return runtime.NewFloatMsg(res), nil
+		},
+		fallible: true,
+	}
+
+	floatGt = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Float() > b.Float()), nil },
+	}
+	floatLt = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Float() < b.Float()), nil },
+	}
+	floatEq = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Float() == b.Float()), nil },
+	}
+)
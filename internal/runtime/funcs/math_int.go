@@ -0,0 +1,92 @@
+package funcs
+
+import (
+	"errors"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+var (
+	intDiv = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			if el.Int() == 0 {
+				return nil, errDivisionByZero
+			}
+			return runtime.NewIntMsg(acc.Int() / el.Int()), nil
+		},
+		fallible: true,
+	}
+	intRem = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			if el.Int() == 0 {
+				return nil, errDivisionByZero
+			}
+			return runtime.NewIntMsg(acc.Int() % el.Int()), nil
+		},
+		fallible: true,
+	}
+	intMin = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewIntMsg(min(acc.Int(), el.Int())), nil
+		},
+	}
+	intMax = streamReducer{
+		op: func(acc, el runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewIntMsg(max(acc.Int(), el.Int())), nil
+		},
+	}
+
+	intNeg = unaryOp{
+		in:  "n",
+		out: "n",
+		op:  func(n runtime.Msg) runtime.Msg { return runtime.NewIntMsg(-n.Int()) },
+	}
+	intAbs = unaryOp{
+		in:  "n",
+		out: "n",
+		op: func(n runtime.Msg) runtime.Msg {
+			if n.Int() < 0 {
+				return runtime.NewIntMsg(-n.Int())
+			}
+			return n
+		},
+	}
+
+	intPow = binaryOp{
+		in:       [2]string{"base", "exp"},
+		op:       intPowOp,
+		fallible: true,
+	}
+
+	intGt = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Int() > b.Int()), nil },
+	}
+	intLt = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Int() < b.Int()), nil },
+	}
+	intEq = binaryOp{
+		in: [2]string{"left", "right"},
+		op: func(a, b runtime.Msg) (runtime.Msg, error) { return runtime.NewBoolMsg(a.Int() == b.Int()), nil },
+	}
+)
+
+// intPowOp uses exponentiation by squaring, result overflows silently just like other int operations.
+func intPowOp(base, exp runtime.Msg) (runtime.Msg, error) {
+	b, e := base.Int(), exp.Int()
+	if e < 0 {
+		return nil, errors.New("negative exponent")
+	}
+
+	var res int64 = 1
+	for e > 0 {
+		if e&1 == 1 {
+			res *= b
+		}
+		b *= b
+		e >>= 1
+	}
+
+	return runtime.NewIntMsg(res), nil
+}
@@ -8,6 +8,10 @@ import (
 
 type new struct{}
 
+func (new) Ports() (in, out []string) {
+	return nil, []string{"msg"}
+}
+
 func (c new) Create(io runtime.FuncIO, msg runtime.Msg) (func(ctx context.Context), error) {
 	outport, err := io.Out.Port("msg")
 	if err != nil {
@@ -0,0 +1,195 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+var errDivisionByZero = errors.New("division by zero")
+
+// streamReducer folds every stream into a single message, starting with its first item.
+// If fallible, first error of the op is sent to err instead of result once the stream is over.
+type streamReducer struct {
+	op       func(acc, el runtime.Msg) (runtime.Msg, error)
+	fallible bool
+}
+
+func (r streamReducer) Ports() (in, out []string) {
+	if r.fallible {
+		return []string{"seq"}, []string{"res", "err"}
+	}
+	return []string{"seq"}, []string{"res"}
+}
+
+func (r streamReducer) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	var errOut chan runtime.Msg
+	if r.fallible {
+		errOut, err = io.Out.Port("err")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(ctx context.Context) {
+		var (
+			acc    runtime.Msg
+			opErr  error
+			cursor int
+		)
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			switch {
+			case cursor == 0:
+				acc = item["data"]
+			case opErr == nil:
+				acc, opErr = r.op(acc, item["data"])
+			}
+			cursor++
+
+			if !item["last"].Bool() {
+				continue
+			}
+
+			out, msg := resOut, acc
+			if opErr != nil {
+				out, msg = errOut, errorFromString(opErr.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- msg:
+				acc, opErr, cursor = nil, nil, 0
+			}
+		}
+	}, nil
+}
+
+// unaryOp sends result of op for every received message.
+type unaryOp struct {
+	in, out string
+	op      func(runtime.Msg) runtime.Msg
+}
+
+func (u unaryOp) Ports() (in, out []string) {
+	return []string{u.in}, []string{u.out}
+}
+
+func (u unaryOp) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	msgIn, err := io.In.Port(u.in)
+	if err != nil {
+		return nil, err
+	}
+
+	msgOut, err := io.Out.Port(u.out)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var msg runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg = <-msgIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case msgOut <- u.op(msg):
+			}
+		}
+	}, nil
+}
+
+// binaryOp waits for both operands and sends result of op.
+// If fallible, errors of the op are sent to err.
+type binaryOp struct {
+	in       [2]string
+	op       func(a, b runtime.Msg) (runtime.Msg, error)
+	fallible bool
+}
+
+func (b binaryOp) Ports() (in, out []string) {
+	if b.fallible {
+		return b.in[:], []string{"res", "err"}
+	}
+	return b.in[:], []string{"res"}
+}
+
+func (b binaryOp) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	aIn, err := io.In.Port(b.in[0])
+	if err != nil {
+		return nil, err
+	}
+
+	bIn, err := io.In.Port(b.in[1])
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	var errOut chan runtime.Msg
+	if b.fallible {
+		errOut, err = io.Out.Port("err")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var x, y runtime.Msg
+
+			select {
+			case <-ctx.Done():
+				return
+			case x = <-aIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case y = <-bIn:
+			}
+
+			res, err := b.op(x, y)
+			out, msg := resOut, res
+			if err != nil {
+				out, msg = errOut, errorFromString(err.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- msg:
+			}
+		}
+	}, nil
+}
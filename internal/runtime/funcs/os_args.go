@@ -0,0 +1,48 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osArgs struct{}
+
+func (osArgs) Ports() (in, out []string) {
+	return []string{"sig"}, []string{"data"}
+}
+
+func (osArgs) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	sigIn, err := io.In.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	dataOut, err := io.Out.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		args := runtime.Args(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigIn:
+			}
+
+			list := make([]runtime.Msg, len(args))
+			for i, arg := range args {
+				list[i] = runtime.NewStrMsg(arg)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case dataOut <- runtime.NewListMsg(list...):
+			}
+		}
+	}, nil
+}
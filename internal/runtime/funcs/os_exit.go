@@ -0,0 +1,28 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osExit struct{}
+
+func (osExit) Ports() (in, out []string) {
+	return []string{"code"}, nil
+}
+
+func (osExit) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	codeIn, err := io.In.Port("code")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+		case codeMsg := <-codeIn:
+			runtime.Exit(ctx, codeMsg.Int())
+		}
+	}, nil
+}
@@ -0,0 +1,43 @@
+package funcs
+
+import (
+	"context"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osGetenv struct{}
+
+func (osGetenv) Ports() (in, out []string) {
+	return []string{"key"}, []string{"res"}
+}
+
+func (osGetenv) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	keyIn, err := io.In.Port("key")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var keyMsg runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case keyMsg = <-keyIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewStrMsg(os.Getenv(keyMsg.Str())):
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,57 @@
+package funcs
+
+import (
+	"context"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osGetwd struct{}
+
+func (osGetwd) Ports() (in, out []string) {
+	return []string{"sig"}, []string{"res", "err"}
+}
+
+func (osGetwd) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	sigIn, err := io.In.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigIn:
+			}
+
+			wd, err := os.Getwd()
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+				}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewStrMsg(wd):
+			}
+		}
+	}, nil
+}
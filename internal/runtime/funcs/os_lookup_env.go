@@ -0,0 +1,48 @@
+package funcs
+
+import (
+	"context"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osLookupEnv struct{}
+
+func (osLookupEnv) Ports() (in, out []string) {
+	return []string{"key"}, []string{"res"}
+}
+
+func (osLookupEnv) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	keyIn, err := io.In.Port("key")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var keyMsg runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case keyMsg = <-keyIn:
+			}
+
+			var resMsg runtime.Msg // nil is none of maybe<string>
+			if value, ok := os.LookupEnv(keyMsg.Str()); ok {
+				resMsg = runtime.NewStrMsg(value)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- resMsg:
+			}
+		}
+	}, nil
+}
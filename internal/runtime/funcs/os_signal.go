@@ -0,0 +1,45 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type osSignal struct{}
+
+func (osSignal) Ports() (in, out []string) {
+	return nil, []string{"msg"}
+}
+
+// SignalPort implements runtime.SignalFunc so signals are not missed while the func is starting.
+func (osSignal) SignalPort(io runtime.FuncIO) (chan runtime.Msg, error) {
+	return io.Out.Port("msg")
+}
+
+func (osSignal) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	msgOut, err := io.Out.Port("msg")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		signals := runtime.Signals(ctx, msgOut)
+
+		for {
+			var sig string
+
+			select {
+			case <-ctx.Done():
+				return
+			case sig = <-signals: // nil channel if signals are not handled, so we just wait for the end
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case msgOut <- runtime.NewStrMsg(sig):
+			}
+		}
+	}, nil
+}
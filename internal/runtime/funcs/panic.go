@@ -2,13 +2,16 @@ package funcs
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/nevalang/neva/internal/runtime"
 )
 
 type panicker struct{}
 
+func (panicker) Ports() (in, out []string) {
+	return []string{"msg"}, nil
+}
+
 func (p panicker) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
@@ -22,9 +25,7 @@ func (p panicker) Create(
 		case <-ctx.Done():
 			return
 		case panicMsg := <-msgIn:
-			cancel := ctx.Value("cancel").(context.CancelFunc)
-			cancel()
-			fmt.Printf("panic: %v\n", panicMsg)
+			runtime.Panic(ctx, panicMsg)
 		}
 	}, nil
 }
@@ -9,6 +9,10 @@ import (
 
 type arrayPortToStream struct{}
 
+func (arrayPortToStream) Ports() (in, out []string) {
+	return []string{"port"}, []string{"seq"}
+}
+
 func (arrayPortToStream) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
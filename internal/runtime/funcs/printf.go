@@ -11,6 +11,10 @@ import (
 
 type printf struct{}
 
+func (printf) Ports() (in, out []string) {
+	return []string{"tpl", "args"}, []string{"args", "err"}
+}
+
 func (p printf) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	tplIn, err := io.In.Port("tpl")
 	if err != nil {
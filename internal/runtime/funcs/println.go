@@ -9,6 +9,10 @@ import (
 
 type println struct{}
 
+func (println) Ports() (in, out []string) {
+	return []string{"data"}, []string{"sig"}
+}
+
 func (p println) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
@@ -8,6 +8,10 @@ import (
 
 type streamIntRange struct{}
 
+func (streamIntRange) Ports() (in, out []string) {
+	return []string{"from", "to"}, []string{"data"}
+}
+
 func (streamIntRange) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
@@ -10,6 +10,10 @@ import (
 
 type readAll struct{}
 
+func (readAll) Ports() (in, out []string) {
+	return []string{"filename"}, []string{"data", "err"}
+}
+
 func (c readAll) Create(rio runtime.FuncIO, msg runtime.Msg) (func(ctx context.Context), error) {
 	filename, err := rio.In.Port("filename")
 	if err != nil {
@@ -0,0 +1,96 @@
+package funcs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type readChunks struct{}
+
+func (readChunks) Ports() (in, out []string) {
+	return []string{"filename", "size"}, []string{"seq", "err"}
+}
+
+func (c readChunks) Create(rio runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	filenameIn, err := rio.In.Port("filename")
+	if err != nil {
+		return nil, err
+	}
+
+	sizeIn, err := rio.In.Port("size")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := rio.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := rio.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var name, size runtime.Msg
+
+			select {
+			case <-ctx.Done():
+				return
+			case name = <-filenameIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case size = <-sizeIn:
+			}
+
+			err := c.read(ctx, name.Str(), int(size.Int()), seqOut)
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case errOut <- errorFromString(err.Error()):
+			}
+		}
+	}, nil
+}
+
+// read sends chunks of size bytes, the last one can be shorter.
+func (readChunks) read(ctx context.Context, name string, size int, seqOut chan<- runtime.Msg) error {
+	if size <= 0 {
+		return fmt.Errorf("chunk size must be positive: %d", size)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	return sendStream(ctx, seqOut, func() (runtime.Msg, error) {
+		buf := make([]byte, size)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && !(errors.Is(err, io.ErrUnexpectedEOF) && n > 0) {
+			return nil, err
+		}
+		return runtime.NewStrMsg(string(buf[:n])), nil
+	})
+}
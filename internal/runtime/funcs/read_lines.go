@@ -0,0 +1,81 @@
+package funcs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type readLines struct{}
+
+func (readLines) Ports() (in, out []string) {
+	return []string{"filename"}, []string{"seq", "err"}
+}
+
+func (c readLines) Create(rio runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	filenameIn, err := rio.In.Port("filename")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := rio.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := rio.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var name runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case name = <-filenameIn:
+			}
+
+			err := c.read(ctx, name.Str(), seqOut)
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case errOut <- errorFromString(err.Error()):
+			}
+		}
+	}, nil
+}
+
+// read sends lines without line endings, file isn't loaded into memory.
+func (readLines) read(ctx context.Context, name string, seqOut chan<- runtime.Msg) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	return sendStream(ctx, seqOut, func() (runtime.Msg, error) {
+		line, err := r.ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && line != "") { // last line may have no line ending
+			return nil, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		line = strings.TrimSuffix(line, "\r")
+		return runtime.NewStrMsg(line), nil
+	})
+}
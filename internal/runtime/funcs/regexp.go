@@ -0,0 +1,221 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	goio "io"
+	"regexp"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// regexpFunc applies compiled pattern to messages received by in ports.
+// Pattern is either received by regexp inport together with them (and compiled again only when it changes)
+// or bound to the node as config message and compiled once at startup, such funcs have no err outport.
+type regexpFunc struct {
+	in     []string
+	bound  bool
+	stream bool // apply returns list that is sent as stream to seq outport
+	apply  func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg
+}
+
+func (f regexpFunc) Ports() (in, out []string) {
+	in = f.in
+	out = []string{f.resPort()}
+	if !f.bound {
+		in = append([]string{"regexp"}, in...)
+		out = append(out, "err")
+	}
+	return in, out
+}
+
+func (f regexpFunc) resPort() string {
+	if f.stream {
+		return "seq"
+	}
+	return "res"
+}
+
+func (f regexpFunc) Create(io runtime.FuncIO, patternMsg runtime.Msg) (func(ctx context.Context), error) {
+	var (
+		re       *regexp.Regexp
+		regexpIn chan runtime.Msg
+		errOut   chan runtime.Msg
+		err      error
+	)
+
+	if f.bound {
+		if patternMsg == nil {
+			return nil, errors.New("pattern must be bound to the node")
+		}
+		re, err = regexp.Compile(patternMsg.Str())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		regexpIn, err = io.In.Port("regexp")
+		if err != nil {
+			return nil, err
+		}
+		errOut, err = io.Out.Port("err")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	argsIn := make([]chan runtime.Msg, len(f.in))
+	for i, name := range f.in {
+		argsIn[i], err = io.In.Port(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resOut, err := io.Out.Port(f.resPort())
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var pattern string
+			if !f.bound {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-regexpIn:
+					pattern = msg.Str()
+				}
+			}
+
+			args := make([]runtime.Msg, len(argsIn))
+			for i, argIn := range argsIn {
+				select {
+				case <-ctx.Done():
+					return
+				case args[i] = <-argIn:
+				}
+			}
+
+			if !f.bound && (re == nil || re.String() != pattern) {
+				compiled, err := regexp.Compile(pattern)
+				if err != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case errOut <- errorFromString(err.Error()):
+						continue
+					}
+				}
+				re = compiled
+			}
+
+			res := f.apply(re, args)
+
+			if f.stream {
+				items, i := res.List(), 0
+				if err := sendStream(ctx, resOut, func() (runtime.Msg, error) {
+					if i == len(items) {
+						return nil, goio.EOF
+					}
+					i++
+					return items[i-1], nil
+				}); err != nil {
+					return
+				}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- res:
+			}
+		}
+	}, nil
+}
+
+func regexpMatch(bound bool) regexpFunc {
+	return regexpFunc{
+		in:    []string{"data"},
+		bound: bound,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			return runtime.NewBoolMsg(re.MatchString(args[0].Str()))
+		},
+	}
+}
+
+func regexpSubmatch(bound bool) regexpFunc {
+	return regexpFunc{
+		in:    []string{"data"},
+		bound: bound,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			return strListMsg(re.FindStringSubmatch(args[0].Str()))
+		},
+	}
+}
+
+func regexpFindAll(bound bool) regexpFunc {
+	return regexpFunc{
+		in:    []string{"data"},
+		bound: bound,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			return strListMsg(re.FindAllString(args[0].Str(), -1))
+		},
+	}
+}
+
+func regexpFindAllSubmatch(bound bool) regexpFunc {
+	return regexpFunc{
+		in:     []string{"data"},
+		bound:  bound,
+		stream: true,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			matches := re.FindAllStringSubmatch(args[0].Str(), -1)
+			res := make([]runtime.Msg, len(matches))
+			for i, match := range matches {
+				res[i] = strListMsg(match)
+			}
+			return runtime.NewListMsg(res...)
+		},
+	}
+}
+
+func regexpReplaceAll(bound bool) regexpFunc {
+	return regexpFunc{
+		in:    []string{"data", "repl"},
+		bound: bound,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			return runtime.NewStrMsg(re.ReplaceAllString(args[0].Str(), args[1].Str()))
+		},
+	}
+}
+
+// regexpNamedSubmatch maps names of the groups to the text they matched, unnamed groups are skipped.
+func regexpNamedSubmatch(bound bool) regexpFunc {
+	return regexpFunc{
+		in:    []string{"data"},
+		bound: bound,
+		apply: func(re *regexp.Regexp, args []runtime.Msg) runtime.Msg {
+			res := map[string]runtime.Msg{}
+			match := re.FindStringSubmatch(args[0].Str())
+			if match == nil {
+				return runtime.NewMapMsg(res)
+			}
+			for i, name := range re.SubexpNames() {
+				if i > 0 && name != "" {
+					res[name] = runtime.NewStrMsg(match[i])
+				}
+			}
+			return runtime.NewMapMsg(res)
+		},
+	}
+}
+
+func strListMsg(ss []string) runtime.Msg {
+	msgs := make([]runtime.Msg, 0, len(ss))
+	for _, s := range ss {
+		msgs = append(msgs, runtime.NewStrMsg(s))
+	}
+	return runtime.NewListMsg(msgs...)
+}
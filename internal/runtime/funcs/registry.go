@@ -1,13 +1,75 @@
 // Package funcs implements low-level components (runtime functions).
-// It has only one exported entity which is function creators registry.
+// It exports function creators registry and Register for native funcs shipped by modules.
 package funcs
 
 import (
+	"fmt"
+	"os"
+	"sync"
+
 	"github.com/nevalang/neva/internal/runtime"
 )
 
+var (
+	nativeMu sync.Mutex
+	native   = map[string]runtime.FuncCreator{}
+)
+
+// Register adds native func that neva module ships as a Go package (see native section of the manifest).
+// Modules can't import internal packages so they call it via pkg/runtime.
+// It panics if ref is already taken.
+func Register(ref string, creator runtime.FuncCreator) {
+	if creator == nil {
+		panic(fmt.Sprintf("funcs: creator is nil: %v", ref))
+	}
+	if _, ok := stdFuncs()[ref]; ok {
+		panic(fmt.Sprintf("funcs: ref is already registered: %v", ref))
+	}
+
+	nativeMu.Lock()
+	defer nativeMu.Unlock()
+	native[ref] = creator
+}
+
+// stdFunc always reads the same ports (including array ones),
+// so compiler can check interfaces of components that refer to it via #extern.
+type stdFunc interface {
+	runtime.FuncCreator
+	Ports() (in, out []string)
+}
+
+// FuncPorts implements compiler.FuncPorts for std funcs.
+// Ports of native funcs are only known when the program is built, so they're not here.
+type FuncPorts struct{}
+
+func (FuncPorts) Ports(ref string) (in, out []string, ok bool) {
+	f, ok := stdFuncs()[ref]
+	if !ok {
+		return nil, nil, false
+	}
+	in, out = f.Ports()
+	return in, out, true
+}
+
 func CreatorRegistry() map[string]runtime.FuncCreator {
-	return map[string]runtime.FuncCreator{
+	std := stdFuncs()
+
+	registry := make(map[string]runtime.FuncCreator, len(std)+len(native))
+	for ref, f := range std {
+		registry[ref] = f
+	}
+
+	nativeMu.Lock()
+	defer nativeMu.Unlock()
+	for ref, creator := range native {
+		registry[ref] = creator
+	}
+
+	return registry
+}
+
+func stdFuncs() map[string]stdFunc {
+	return map[string]stdFunc{
 		// core
 		"new":    new{},
 		"del":    del{},
@@ -20,6 +82,14 @@ func CreatorRegistry() map[string]runtime.FuncCreator {
 		"array_port_to_stream": arrayPortToStream{},
 		"list_to_stream":       listToStream{},
 		"stream_int_range":     streamIntRange{},
+		"stream_map":           streamMap{},
+		"stream_filter":        streamFilter{},
+		"stream_reduce":        streamReduce{},
+		"stream_take":          streamTake{},
+		"stream_skip":          streamSkip{},
+		"stream_zip":           streamZip{},
+		"stream_concat":        streamConcat{},
+		"stream_enumerate":     streamEnumerate,
 
 		// builders
 		"struct_builder": structBuilder{},
@@ -34,38 +104,137 @@ func CreatorRegistry() map[string]runtime.FuncCreator {
 		"int_mul":  intMul{},
 		"int_decr": intDecr{},
 		"int_mod":  intMod{},
+		"int_div":  intDiv,
+		"int_rem":  intRem,
+		"int_min":  intMin,
+		"int_max":  intMax,
+		"int_neg":  intNeg,
+		"int_abs":  intAbs,
+		"int_pow":  intPow,
+		"int_gt":   intGt,
+		"int_lt":   intLt,
+		"int_eq":   intEq,
+
+		"float_add":  floatAdd{},
+		"float_sub":  floatSub{},
+		"float_mul":  floatMul{},
+		"float_decr": floatDecr{},
+		"float_div":  floatDiv,
+		"float_rem":  floatRem,
+		"float_min":  floatMin,
+		"float_max":  floatMax,
+		"float_neg":  floatNeg,
+		"float_abs":  floatAbs,
+		"float_pow":  floatPow,
+		"float_gt":   floatGt,
+		"float_lt":   floatLt,
+		"float_eq":   floatEq,
+
+		"string_add": stringAdd{},
 
 		// strconv
-		"parse_int": parseInt{},
+		"parse_int":   parseInt{},
+		"parse_float": parseFloat{},
 
 		// regexp
-		"regexp_submatch": regexpSubmatch{},
+		"regexp_match":                      regexpMatch(false),
+		"regexp_submatch":                   regexpSubmatch(false),
+		"regexp_find_all":                   regexpFindAll(false),
+		"regexp_find_all_submatch":          regexpFindAllSubmatch(false),
+		"regexp_replace_all":                regexpReplaceAll(false),
+		"regexp_named_submatch":             regexpNamedSubmatch(false),
+		"regexp_compiled_match":             regexpMatch(true),
+		"regexp_compiled_submatch":          regexpSubmatch(true),
+		"regexp_compiled_find_all":          regexpFindAll(true),
+		"regexp_compiled_find_all_submatch": regexpFindAllSubmatch(true),
+		"regexp_compiled_replace_all":       regexpReplaceAll(true),
+		"regexp_compiled_named_submatch":    regexpNamedSubmatch(true),
 
 		// list
 		"index":      index{},
 		"list_len":   listlen{},
+		"map_len":    mapLen{},
+		"slice":      slice{},
 		"list_push":  listPush{},
 		"int_sort":   listSortInt{},
 		"float_sort": listSortFloat{},
 
+		// map
+		"map_get":    mapGet,
+		"map_lookup": mapLookup,
+		"map_has":    mapHas,
+		"map_set":    mapSet{},
+		"map_delete": mapDelete,
+		"map_merge":  mapMerge,
+		"map_keys":   mapStream{values: false},
+		"map_values": mapStream{values: true},
+
 		// time
-		"time_sleep": timeSleep{},
+		"time_sleep":     timeSleep{},
+		"time_now":       timeNow,
+		"time_unix_nano": timeUnixNano,
+		"time_since":     timeSince,
+		"time_format":    timeFormat,
+		"time_parse":     timeParse,
+		"time_tick":      timeTick{},
+		"time_after":     timeAfter{},
+		"time_timeout":   timeTimeout{},
 
 		// strings
-		"join":        stringJoin{},
-		"split":       stringSplit{},
-		"string_sort": listSortString{},
+		"join":               stringJoin{},
+		"split":              stringSplit{},
+		"string_sort":        listSortString{},
+		"string_len":         stringLen,
+		"string_contains":    stringContains,
+		"string_has_prefix":  stringHasPrefix,
+		"string_has_suffix":  stringHasSuffix,
+		"string_index":       stringIndex,
+		"string_replace":     stringReplace{},
+		"string_to_upper":    stringToUpper,
+		"string_to_lower":    stringToLower,
+		"string_trim":        stringTrim,
+		"string_trim_space":  stringTrimSpace,
+		"string_trim_prefix": stringTrimPrefix,
+		"string_trim_suffix": stringTrimSuffix,
+		"string_fields":      stringFields,
+		"string_repeat":      stringRepeat,
 
 		// io
 		"scanln":  scanln{},
 		"println": println{},
 		"printf":  printf{},
 
+		// os
+		"os_args":       osArgs{},
+		"os_getenv":     osGetenv{},
+		"os_lookup_env": osLookupEnv{},
+		"os_getwd":      osGetwd{},
+		"os_signal":     osSignal{},
+		"os_exit":       osExit{},
+
 		// io/file
-		"read_all":  readAll{},
-		"write_all": writeAll{},
+		"read_all":     readAll{},
+		"read_lines":   readLines{},
+		"read_chunks":  readChunks{},
+		"write_all":    writeAll{},
+		"write_chunks": writeChunks{},
+		"append":       appendFile{},
+
+		// fs
+		"fs_read_dir":  fsReadDir{},
+		"fs_stat":      fsStat{},
+		"fs_mkdir_all": fsPathOp{op: func(path string) error { return os.MkdirAll(path, 0755) }},
+		"fs_remove":    fsPathOp{op: os.Remove},
+		"fs_rename":    fsRename{},
+		"fs_glob":      fsGlob{},
 		// http
-		"http_get": httpGet{},
+		"http_get":     httpGet{},
+		"http_do":      httpDo{},
+		"http_serve":   httpServe{},
+		"http_respond": httpRespond{},
+		// json
+		"json_marshal":   jsonMarshal{},
+		"json_unmarshal": jsonUnmarshal{},
 		// image
 		"image_encode": imageEncode{},
 		"image_new":    imageNew{},
@@ -0,0 +1,80 @@
+package funcs
+
+import (
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nevalang/neva/internal/compiler"
+	"github.com/nevalang/neva/internal/compiler/parser"
+	src "github.com/nevalang/neva/internal/compiler/sourcecode"
+	"github.com/nevalang/neva/std"
+)
+
+// Every extern declared in std must be implemented by the func that reads exactly the declared ports,
+// otherwise programs fail at startup.
+func TestFuncPorts_MatchStdExterns(t *testing.T) {
+	pkgs := map[string]map[string][]byte{}
+	err := fs.WalkDir(std.FS, ".", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(filePath, ".neva") {
+			return err
+		}
+
+		bb, err := fs.ReadFile(std.FS, filePath)
+		if err != nil {
+			return err
+		}
+
+		pkg := path.Dir(filePath)
+		if pkgs[pkg] == nil {
+			pkgs[pkg] = map[string][]byte{}
+		}
+		pkgs[pkg][strings.TrimSuffix(path.Base(filePath), ".neva")] = bb
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	var checked int
+	for pkg, files := range pkgs {
+		parsed, compilerErr := parser.New(false).ParseFiles(src.ModuleRef{Path: "std"}, pkg, files)
+		require.Nil(t, compilerErr, pkg)
+
+		for fileName, file := range parsed {
+			for name, entity := range file.Entities {
+				if entity.Kind != src.ComponentEntity {
+					continue
+				}
+
+				iface := entity.Component.Interface.IO
+				for _, arg := range entity.Component.Directives[compiler.ExternDirective] {
+					// overloaded externs look like "int int_add"
+					parts := strings.Fields(arg)
+					ref := parts[len(parts)-1]
+					where := pkg + "/" + fileName + ".neva: " + name + ": " + ref
+
+					in, out, ok := FuncPorts{}.Ports(ref)
+					require.True(t, ok, where)
+					require.ElementsMatch(t, portNames(iface.In), in, where)
+					require.ElementsMatch(t, portNames(iface.Out), out, where)
+					checked++
+				}
+			}
+		}
+	}
+
+	require.NotZero(t, checked)
+}
+
+func portNames(ports map[string]src.Port) []string {
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -10,6 +10,10 @@ import (
 
 type scanln struct{}
 
+func (scanln) Ports() (in, out []string) {
+	return []string{"sig"}, []string{"data"}
+}
+
 func (r scanln) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	sigIn, err := io.In.Port("sig")
 	if err != nil {
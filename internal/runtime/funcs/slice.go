@@ -0,0 +1,105 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type slice struct{}
+
+func (slice) Ports() (in, out []string) {
+	return []string{"data", "from", "to"}, []string{"res", "err"}
+}
+
+func (slice) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	fromIn, err := io.In.Port("from")
+	if err != nil {
+		return nil, err
+	}
+
+	toIn, err := io.In.Port("to")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var dataMsg, fromMsg, toMsg runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case dataMsg = <-dataIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case fromMsg = <-fromIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case toMsg = <-toIn:
+			}
+
+			res, err := sliceMsg(dataMsg, fromMsg.Int(), toMsg.Int())
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+				}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- res:
+			}
+		}
+	}, nil
+}
+
+// sliceMsg slices lists by elements and strings by utf-8 characters.
+func sliceMsg(data runtime.Msg, from, to int64) (runtime.Msg, error) {
+	if data.Type() == runtime.StrMsgType {
+		runes := []rune(data.Str())
+		if err := checkSliceBounds(from, to, len(runes)); err != nil {
+			return nil, err
+		}
+		return runtime.NewStrMsg(string(runes[from:to])), nil
+	}
+
+	list := data.List()
+	if err := checkSliceBounds(from, to, len(list)); err != nil {
+		return nil, err
+	}
+	return runtime.NewListMsg(list[from:to]...), nil
+}
+
+func checkSliceBounds(from, to int64, length int) error {
+	if from < 0 || to < from || to > int64(length) {
+		return fmt.Errorf("slice bounds out of range [%d:%d] with length %d", from, to, length)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamConcat sends all items of the first stream and then all items of the second one,
+// numbering them as a single stream.
+type streamConcat struct{}
+
+func (streamConcat) Ports() (in, out []string) {
+	return []string{"first", "second"}, []string{"seq"}
+}
+
+func (streamConcat) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	firstIn, err := io.In.Port("first")
+	if err != nil {
+		return nil, err
+	}
+
+	secondIn, err := io.In.Port("second")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			idx := int64(0)
+
+			for _, in := range []chan runtime.Msg{firstIn, secondIn} {
+				for done := false; !done; idx++ {
+					var item map[string]runtime.Msg
+					select {
+					case <-ctx.Done():
+						return
+					case msg := <-in:
+						item = msg.Map()
+					}
+
+					done = item["last"].Bool()
+
+					select {
+					case <-ctx.Done():
+						return
+					case seqOut <- streamItem(item["data"], idx, done && in == secondIn):
+					}
+				}
+			}
+		}
+	}, nil
+}
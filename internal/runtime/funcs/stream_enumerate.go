@@ -0,0 +1,20 @@
+package funcs
+
+import "github.com/nevalang/neva/internal/runtime"
+
+// streamEnumerate puts index of every stream item next to its data, so it's available after Map.
+var streamEnumerate = unaryOp{
+	in:  "seq",
+	out: "seq",
+	op: func(msg runtime.Msg) runtime.Msg {
+		item := msg.Map()
+		return streamItem(
+			runtime.NewMapMsg(map[string]runtime.Msg{
+				"idx":  item["idx"],
+				"data": item["data"],
+			}),
+			item["idx"].Int(),
+			item["last"].Bool(),
+		)
+	},
+}
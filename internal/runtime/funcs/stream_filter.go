@@ -0,0 +1,92 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamFilter sends data of every stream item to predicate and keeps items it's true for.
+// Kept items are numbered again. One of them is held back until the next one is kept
+// or the stream is over, so the last flag can be set on it. If no items are kept nothing is sent.
+type streamFilter struct{}
+
+func (streamFilter) Ports() (in, out []string) {
+	return []string{"seq", "keep"}, []string{"data", "seq"}
+}
+
+func (streamFilter) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	keepIn, err := io.In.Port("keep")
+	if err != nil {
+		return nil, err
+	}
+
+	dataOut, err := io.Out.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var (
+			pending    runtime.Msg // kept item that isn't sent yet
+			hasPending bool
+			idx        int64
+		)
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case dataOut <- item["data"]:
+			}
+
+			var keep runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case keep = <-keepIn:
+			}
+
+			if keep.Bool() {
+				if hasPending {
+					select {
+					case <-ctx.Done():
+						return
+					case seqOut <- streamItem(pending, idx, false):
+						idx++
+					}
+				}
+				pending, hasPending = item["data"], true
+			}
+
+			if !item["last"].Bool() || !hasPending {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case seqOut <- streamItem(pending, idx, true):
+				pending, hasPending, idx = nil, false, 0
+			}
+		}
+	}, nil
+}
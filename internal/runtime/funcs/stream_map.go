@@ -0,0 +1,68 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamMap sends data of every stream item to mapper and waits for the result,
+// so items are mapped one by one and keep their order, idx and last.
+type streamMap struct{}
+
+func (streamMap) Ports() (in, out []string) {
+	return []string{"seq", "mapped"}, []string{"data", "seq"}
+}
+
+func (streamMap) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	mappedIn, err := io.In.Port("mapped")
+	if err != nil {
+		return nil, err
+	}
+
+	dataOut, err := io.Out.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case dataOut <- item["data"]:
+			}
+
+			var mapped runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case mapped = <-mappedIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case seqOut <- streamItem(mapped, item["idx"].Int(), item["last"].Bool()):
+			}
+		}
+	}, nil
+}
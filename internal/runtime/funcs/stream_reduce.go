@@ -0,0 +1,103 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamReduce folds every stream with reducer starting from init,
+// which is received once per stream when its first item arrives.
+// Reducer gets accumulator and data of the item and sends new accumulator back.
+type streamReduce struct{}
+
+func (streamReduce) Ports() (in, out []string) {
+	return []string{"seq", "init", "reduced"}, []string{"acc", "el", "res"}
+}
+
+func (streamReduce) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	initIn, err := io.In.Port("init")
+	if err != nil {
+		return nil, err
+	}
+
+	reducedIn, err := io.In.Port("reduced")
+	if err != nil {
+		return nil, err
+	}
+
+	accOut, err := io.Out.Port("acc")
+	if err != nil {
+		return nil, err
+	}
+
+	elOut, err := io.Out.Port("el")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var (
+			acc   runtime.Msg
+			first = true
+		)
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			if first {
+				select {
+				case <-ctx.Done():
+					return
+				case acc = <-initIn:
+					first = false
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case accOut <- acc:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case elOut <- item["data"]:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case acc = <-reducedIn:
+			}
+
+			if !item["last"].Bool() {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- acc:
+				acc, first = nil, true
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,73 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamTake sends first n items of every stream, n is received once per stream when its first item arrives.
+// Item number n becomes the last one and the rest of the stream is dropped.
+// If n isn't positive nothing is sent.
+type streamTake struct{}
+
+func (streamTake) Ports() (in, out []string) {
+	return []string{"seq", "n"}, []string{"seq"}
+}
+
+func (streamTake) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	nIn, err := io.In.Port("n")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var (
+			n     int64
+			first = true
+		)
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			if first {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-nIn:
+					n, first = msg.Int(), false
+				}
+			}
+
+			idx, last := item["idx"].Int(), item["last"].Bool()
+
+			if idx < n {
+				select {
+				case <-ctx.Done():
+					return
+				case seqOut <- streamItem(item["data"], idx, last || idx == n-1):
+				}
+			}
+
+			if last {
+				first = true
+			}
+		}
+	}, nil
+}
@@ -8,6 +8,10 @@ import (
 
 type streamToList struct{}
 
+func (streamToList) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
 func (s streamToList) Create(
 	io runtime.FuncIO,
 	_ runtime.Msg,
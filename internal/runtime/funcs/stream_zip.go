@@ -0,0 +1,83 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+// streamZip pairs items of two streams until one of them is over.
+// The rest of the longer stream is dropped.
+type streamZip struct{}
+
+func (streamZip) Ports() (in, out []string) {
+	return []string{"left", "right"}, []string{"seq"}
+}
+
+func (streamZip) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	leftIn, err := io.In.Port("left")
+	if err != nil {
+		return nil, err
+	}
+
+	rightIn, err := io.In.Port("right")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var left, right map[string]runtime.Msg
+
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-leftIn:
+				left = msg.Map()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-rightIn:
+				right = msg.Map()
+			}
+
+			leftLast, rightLast := left["last"].Bool(), right["last"].Bool()
+
+			pair := runtime.NewMapMsg(map[string]runtime.Msg{
+				"left":  left["data"],
+				"right": right["data"],
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			case seqOut <- streamItem(pair, left["idx"].Int(), leftLast || rightLast):
+			}
+
+			if leftLast == rightLast {
+				continue
+			}
+
+			// drop the rest of the longer stream, so its next one is paired from the beginning
+			rest := leftIn
+			if leftLast {
+				rest = rightIn
+			}
+			for done := false; !done; {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-rest:
+					done = msg.Map()["last"].Bool()
+				}
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,55 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type stringAdd struct{}
+
+func (stringAdd) Ports() (in, out []string) {
+	return []string{"seq"}, []string{"res"}
+}
+
+func (stringAdd) Create(
+	io runtime.FuncIO,
+	_ runtime.Msg,
+) (func(ctx context.Context), error) {
+	seqIn, err := io.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var acc strings.Builder
+
+		for {
+			var item map[string]runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-seqIn:
+				item = msg.Map()
+			}
+
+			acc.WriteString(item["data"].Str())
+
+			if item["last"].Bool() {
+				select {
+				case <-ctx.Done():
+					return
+				case resOut <- runtime.NewStrMsg(acc.String()):
+					acc.Reset()
+					continue
+				}
+			}
+		}
+	}, nil
+}
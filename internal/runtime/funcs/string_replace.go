@@ -0,0 +1,66 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type stringReplace struct{}
+
+func (stringReplace) Ports() (in, out []string) {
+	return []string{"data", "old", "new"}, []string{"res"}
+}
+
+func (stringReplace) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	oldIn, err := io.In.Port("old")
+	if err != nil {
+		return nil, err
+	}
+
+	newIn, err := io.In.Port("new")
+	if err != nil {
+		return nil, err
+	}
+
+	resOut, err := io.Out.Port("res")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var data, oldMsg, newMsg runtime.Msg
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data = <-dataIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case oldMsg = <-oldIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case newMsg = <-newIn:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case resOut <- runtime.NewStrMsg(strings.ReplaceAll(data.Str(), oldMsg.Str(), newMsg.Str())):
+			}
+		}
+	}, nil
+}
@@ -9,6 +9,10 @@ import (
 
 type stringSplit struct{}
 
+func (stringSplit) Ports() (in, out []string) {
+	return []string{"data", "delim"}, []string{"res"}
+}
+
 func (p stringSplit) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
@@ -0,0 +1,109 @@
+package funcs
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+var (
+	stringLen = unaryOp{
+		in:  "data",
+		out: "res",
+		op: func(data runtime.Msg) runtime.Msg {
+			return runtime.NewIntMsg(int64(utf8.RuneCountInString(data.Str())))
+		},
+	}
+	stringToUpper = unaryOp{
+		in:  "data",
+		out: "res",
+		op:  func(data runtime.Msg) runtime.Msg { return runtime.NewStrMsg(strings.ToUpper(data.Str())) },
+	}
+	stringToLower = unaryOp{
+		in:  "data",
+		out: "res",
+		op:  func(data runtime.Msg) runtime.Msg { return runtime.NewStrMsg(strings.ToLower(data.Str())) },
+	}
+	stringTrimSpace = unaryOp{
+		in:  "data",
+		out: "res",
+		op:  func(data runtime.Msg) runtime.Msg { return runtime.NewStrMsg(strings.TrimSpace(data.Str())) },
+	}
+	stringFields = unaryOp{
+		in:  "data",
+		out: "res",
+		op: func(data runtime.Msg) runtime.Msg {
+			fields := strings.Fields(data.Str())
+			res := make([]runtime.Msg, len(fields))
+			for i, field := range fields {
+				res[i] = runtime.NewStrMsg(field)
+			}
+			return runtime.NewListMsg(res...)
+		},
+	}
+
+	stringContains = binaryOp{
+		in: [2]string{"data", "substr"},
+		op: func(data, substr runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewBoolMsg(strings.Contains(data.Str(), substr.Str())), nil
+		},
+	}
+	stringHasPrefix = binaryOp{
+		in: [2]string{"data", "prefix"},
+		op: func(data, prefix runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewBoolMsg(strings.HasPrefix(data.Str(), prefix.Str())), nil
+		},
+	}
+	stringHasSuffix = binaryOp{
+		in: [2]string{"data", "suffix"},
+		op: func(data, suffix runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewBoolMsg(strings.HasSuffix(data.Str(), suffix.Str())), nil
+		},
+	}
+	// index is counted in characters, not bytes, just like Len
+	stringIndex = binaryOp{
+		in: [2]string{"data", "substr"},
+		op: func(data, substr runtime.Msg) (runtime.Msg, error) {
+			i := strings.Index(data.Str(), substr.Str())
+			if i == -1 {
+				return runtime.NewIntMsg(-1), nil
+			}
+			return runtime.NewIntMsg(int64(utf8.RuneCountInString(data.Str()[:i]))), nil
+		},
+	}
+	stringTrim = binaryOp{
+		in: [2]string{"data", "cutset"},
+		op: func(data, cutset runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewStrMsg(strings.Trim(data.Str(), cutset.Str())), nil
+		},
+	}
+	stringTrimPrefix = binaryOp{
+		in: [2]string{"data", "prefix"},
+		op: func(data, prefix runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewStrMsg(strings.TrimPrefix(data.Str(), prefix.Str())), nil
+		},
+	}
+	stringTrimSuffix = binaryOp{
+		in: [2]string{"data", "suffix"},
+		op: func(data, suffix runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewStrMsg(strings.TrimSuffix(data.Str(), suffix.Str())), nil
+		},
+	}
+	stringRepeat = binaryOp{
+		in: [2]string{"data", "count"},
+		op: func(data, count runtime.Msg) (runtime.Msg, error) {
+			if count.Int() < 0 {
+				return nil, errors.New("negative repeat count")
+			}
+			// strings.Repeat panics if result is too long
+			if n := len(data.Str()); n > 0 && count.Int() > int64(math.MaxInt/n) {
+				return nil, errors.New("repeat count causes overflow")
+			}
+			return runtime.NewStrMsg(strings.Repeat(data.Str(), int(count.Int()))), nil
+		},
+		fallible: true,
+	}
+)
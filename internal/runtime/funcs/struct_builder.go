@@ -9,6 +9,11 @@ import (
 
 type structBuilder struct{}
 
+// Ports are the ones declared by Struct component, inports are added by desugarer for every field (see #autoports).
+func (structBuilder) Ports() (in, out []string) {
+	return nil, []string{"msg"}
+}
+
 func (s structBuilder) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	if len(io.In) == 0 {
 		return nil, errors.New("cannot create struct builder without inports")
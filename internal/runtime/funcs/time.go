@@ -0,0 +1,59 @@
+package funcs
+
+import (
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+var (
+	timeNow = unaryOp{
+		in:  "sig",
+		out: "res",
+		op:  func(runtime.Msg) runtime.Msg { return timeMsg(time.Now()) },
+	}
+	timeUnixNano = unaryOp{
+		in:  "sig",
+		out: "res",
+		op:  func(runtime.Msg) runtime.Msg { return runtime.NewIntMsg(time.Now().UnixNano()) },
+	}
+	timeSince = unaryOp{
+		in:  "ns",
+		out: "res",
+		op: func(ns runtime.Msg) runtime.Msg {
+			return runtime.NewIntMsg(int64(time.Since(time.Unix(0, ns.Int()))))
+		},
+	}
+	timeFormat = binaryOp{
+		in: [2]string{"ns", "layout"},
+		op: func(ns, layout runtime.Msg) (runtime.Msg, error) {
+			return runtime.NewStrMsg(time.Unix(0, ns.Int()).Format(layout.Str())), nil
+		},
+	}
+	timeParse = binaryOp{
+		in: [2]string{"data", "layout"},
+		op: func(data, layout runtime.Msg) (runtime.Msg, error) {
+			t, err := time.Parse(layout.Str(), data.Str())
+			if err != nil {
+				return nil, err
+			}
+			return runtime.NewIntMsg(t.UnixNano()), nil
+		},
+		fallible: true,
+	}
+)
+
+// timeMsg builds Time struct from std/time, in local time zone.
+func timeMsg(t time.Time) runtime.Msg {
+	return runtime.NewMapMsg(map[string]runtime.Msg{
+		"unixNano":   runtime.NewIntMsg(t.UnixNano()),
+		"year":       runtime.NewIntMsg(int64(t.Year())),
+		"month":      runtime.NewIntMsg(int64(t.Month())),
+		"day":        runtime.NewIntMsg(int64(t.Day())),
+		"hour":       runtime.NewIntMsg(int64(t.Hour())),
+		"minute":     runtime.NewIntMsg(int64(t.Minute())),
+		"second":     runtime.NewIntMsg(int64(t.Second())),
+		"nanosecond": runtime.NewIntMsg(int64(t.Nanosecond())),
+		"weekday":    runtime.NewIntMsg(int64(t.Weekday())),
+	})
+}
@@ -0,0 +1,61 @@
+package funcs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type timeAfter struct{}
+
+func (timeAfter) Ports() (in, out []string) {
+	return []string{"ns"}, []string{"sig"}
+}
+
+// Unlike Sleep, After doesn't block on the current timer, every received duration starts its own one.
+func (timeAfter) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	nsIn, err := io.In.Port("ns")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := io.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		for {
+			var ns runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case ns = <-nsIn:
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				timer := time.NewTimer(time.Duration(ns.Int()))
+				defer timer.Stop()
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-timer.C:
+				}
+
+				select {
+				case <-ctx.Done():
+				case sigOut <- nil:
+				}
+			}()
+		}
+	}, nil
+}
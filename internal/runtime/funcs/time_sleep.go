@@ -9,6 +9,10 @@ import (
 
 type timeSleep struct{}
 
+func (timeSleep) Ports() (in, out []string) {
+	return []string{"ns"}, []string{"sig"}
+}
+
 func (timeSleep) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	nsIn, err := io.In.Port("ns")
 	if err != nil {
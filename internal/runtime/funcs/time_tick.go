@@ -0,0 +1,103 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type timeTick struct{}
+
+func (timeTick) Ports() (in, out []string) {
+	return []string{"interval", "stop"}, []string{"seq", "err"}
+}
+
+func (timeTick) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	intervalIn, err := io.In.Port("interval")
+	if err != nil {
+		return nil, err
+	}
+
+	stopIn, err := io.In.Port("stop")
+	if err != nil {
+		return nil, err
+	}
+
+	seqOut, err := io.Out.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var interval runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopIn: // nothing to stop
+				continue
+			case interval = <-intervalIn:
+			}
+
+			// ticker panics on such intervals
+			if interval.Int() <= 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(fmt.Sprintf("non-positive tick interval: %d", interval.Int())):
+					continue
+				}
+			}
+
+			if !tick(ctx, time.Duration(interval.Int()), stopIn, seqOut) {
+				return
+			}
+		}
+	}, nil
+}
+
+// tick streams time of every tick until stop message, which is answered with the last item.
+// It returns false if context is done.
+func tick(ctx context.Context, interval time.Duration, stopIn <-chan runtime.Msg, seqOut chan<- runtime.Msg) bool {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for idx := int64(0); ; idx++ {
+		var (
+			t    time.Time
+			last bool
+		)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case t = <-ticker.C:
+		case <-stopIn:
+			t, last = time.Now(), true
+		}
+
+		// receivers might be slow, so stop can also arrive while item is waiting to be sent,
+		// in that case the item becomes the last one
+		for sent := false; !sent; {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-stopIn:
+				last = true
+			case seqOut <- streamItem(runtime.NewIntMsg(t.UnixNano()), idx, last):
+				sent = true
+			}
+		}
+
+		if last {
+			return true
+		}
+	}
+}
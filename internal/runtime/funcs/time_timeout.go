@@ -0,0 +1,94 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type timeTimeout struct{}
+
+func (timeTimeout) Ports() (in, out []string) {
+	return []string{"ns", "data"}, []string{"data", "err"}
+}
+
+func (timeTimeout) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	nsIn, err := io.In.Port("ns")
+	if err != nil {
+		return nil, err
+	}
+
+	dataIn, err := io.In.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	dataOut, err := io.Out.Port("data")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := io.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		var (
+			d      time.Duration
+			timer  *time.Timer
+			timerC <-chan time.Time // nil if there's no round in progress
+			// late is set when round times out and is cleared when the next one starts,
+			// data received between these moments belongs to the timed out round and is dropped.
+			late bool
+		)
+
+		for {
+			// new round starts only when the previous one is over,
+			// while there's no round data is only received to be dropped
+			nsCh, dataCh := nsIn, dataIn
+			if timerC != nil {
+				nsCh = nil
+			} else if !late {
+				dataCh = nil
+			}
+
+			var (
+				out chan<- runtime.Msg
+				msg runtime.Msg
+			)
+
+			select {
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case ns := <-nsCh:
+				d = time.Duration(ns.Int())
+				timer = time.NewTimer(d)
+				timerC, late = timer.C, false
+				continue
+			case msg = <-dataCh:
+				if timerC == nil { // late
+					late = false
+					continue
+				}
+				timer.Stop()
+				timerC = nil
+				out = dataOut
+			case <-timerC:
+				timerC, late = nil, true
+				out, msg = errOut, errorFromString(fmt.Sprintf("timeout after %v", d))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- msg:
+			}
+		}
+	}, nil
+}
@@ -8,6 +8,10 @@ import (
 
 type unwrap struct{}
 
+func (unwrap) Ports() (in, out []string) {
+	return []string{"data"}, []string{"some", "none"}
+}
+
 func (unwrap) Create(io runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
 	dataIn, err := io.In.Port("data")
 	if err != nil {
@@ -1,6 +1,12 @@
 package funcs
 
-import "github.com/nevalang/neva/internal/runtime"
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
 
 func errorFromString(s string) runtime.Msg {
 	return runtime.NewMapMsg(map[string]runtime.Msg{
@@ -15,3 +21,37 @@ func streamItem(data runtime.Msg, idx int64, last bool) runtime.Msg {
 		"last": runtime.NewBoolMsg(last),
 	})
 }
+
+// sendStream sends values returned by next as stream items until next returns io.EOF.
+// It reads one value ahead to know which item is the last. If next fails, value that was read before
+// is sent as the last item so the stream is always finished. Nothing is sent if there are no values.
+// It returns ctx.Err() if context is done.
+func sendStream(ctx context.Context, seqOut chan<- runtime.Msg, next func() (runtime.Msg, error)) error {
+	prev, err := next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	for idx := int64(0); ; idx++ {
+		cur, err := next()
+		last := err != nil
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case seqOut <- streamItem(prev, idx, last):
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		prev = cur
+	}
+}
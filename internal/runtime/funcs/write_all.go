@@ -7,8 +7,15 @@ import (
 	"github.com/nevalang/neva/internal/runtime"
 )
 
+// fileMode is the permissions of files created by WriteAll, WriteChunks and Append (before umask).
+const fileMode = 0644
+
 type writeAll struct{}
 
+func (writeAll) Ports() (in, out []string) {
+	return []string{"filename", "data"}, []string{"sig", "err"}
+}
+
 func (c writeAll) Create(rio runtime.FuncIO, msg runtime.Msg) (func(ctx context.Context), error) {
 	filename, err := rio.In.Port("filename")
 	if err != nil {
@@ -45,7 +52,7 @@ func (c writeAll) Create(rio runtime.FuncIO, msg runtime.Msg) (func(ctx context.
 			case data = <-dataPort:
 			}
 
-			err := os.WriteFile(name.Str(), []byte(data.Str()), 0755)
+			err := os.WriteFile(name.Str(), []byte(data.Str()), fileMode)
 			if err != nil {
 				select {
 				case <-ctx.Done():
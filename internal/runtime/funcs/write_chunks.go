@@ -0,0 +1,112 @@
+package funcs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+
+	"github.com/nevalang/neva/internal/runtime"
+)
+
+type writeChunks struct{}
+
+func (writeChunks) Ports() (in, out []string) {
+	return []string{"filename", "seq"}, []string{"sig", "err"}
+}
+
+func (c writeChunks) Create(rio runtime.FuncIO, _ runtime.Msg) (func(ctx context.Context), error) {
+	filenameIn, err := rio.In.Port("filename")
+	if err != nil {
+		return nil, err
+	}
+
+	seqIn, err := rio.In.Port("seq")
+	if err != nil {
+		return nil, err
+	}
+
+	sigOut, err := rio.Out.Port("sig")
+	if err != nil {
+		return nil, err
+	}
+
+	errOut, err := rio.Out.Port("err")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		for {
+			var name runtime.Msg
+			select {
+			case <-ctx.Done():
+				return
+			case name = <-filenameIn:
+			}
+
+			err := c.write(ctx, name.Str(), seqIn)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case errOut <- errorFromString(err.Error()):
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sigOut <- nil:
+			}
+		}
+	}, nil
+}
+
+// write keeps file open until the last item. After failure it still reads the rest
+// of the stream so the sender isn't blocked, and returns the first error.
+func (writeChunks) write(ctx context.Context, name string, seqIn <-chan runtime.Msg) error {
+	f, openErr := os.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileMode)
+
+	var w *bufio.Writer
+	if openErr == nil {
+		w = bufio.NewWriter(f)
+	}
+
+	var writeErr error
+	for {
+		var item map[string]runtime.Msg
+		select {
+		case <-ctx.Done():
+			if f != nil {
+				f.Close()
+			}
+			return ctx.Err()
+		case msg := <-seqIn:
+			item = msg.Map()
+		}
+
+		if openErr == nil && writeErr == nil {
+			_, writeErr = w.WriteString(item["data"].Str())
+		}
+
+		if item["last"].Bool() {
+			break
+		}
+	}
+
+	if openErr != nil {
+		return openErr
+	}
+	if writeErr != nil {
+		f.Close()
+		return writeErr
+	}
+
+	return errors.Join(w.Flush(), f.Close())
+}
@@ -2,9 +2,29 @@ package ir
 
 // Program represents the main structure containing ports, connections, and funcs.
 type Program struct {
-	Ports       []PortInfo   `json:"ports,omitempty"`
-	Connections []Connection `json:"connections,omitempty"`
-	Funcs       []FuncCall   `json:"funcs,omitempty"`
+	Ports       []PortInfo                `json:"ports,omitempty"`
+	Connections []Connection              `json:"connections,omitempty"`
+	Funcs       []FuncCall                `json:"funcs,omitempty"`
+	SourceMap   map[string]SourceLocation `json:"source_map,omitempty"` // Node path -> where node is declared
+	// StopIsExitCode is true if Main's stop outport is of type int and its message must be used as exit code.
+	StopIsExitCode bool `json:"stop_is_exit_code,omitempty"`
+	// Native are Go packages that modules ship to implement their own funcs. They must be compiled with runtime.
+	Native []NativePackage `json:"native,omitempty"`
+}
+
+// NativePackage is Go package that registers native funcs of the module via github.com/nevalang/neva/pkg/runtime.Register.
+type NativePackage struct {
+	Module string            `json:"module,omitempty"`
+	Files  map[string][]byte `json:"files,omitempty"` // File name -> Go source code
+}
+
+// SourceLocation points to the place in source code where node is declared.
+// Virtual nodes created by compiler point to the connection they were created for.
+type SourceLocation struct {
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Component string `json:"component,omitempty"` // Component that node is instance of
 }
 
 // PortInfo contains information about each port.
@@ -44,6 +64,17 @@ type FuncIO struct {
 	Out []PortAddr `json:"out,omitempty"` // Must be ordered by path -> port -> idx
 }
 
+// Path returns path of any port of the func so the node that calls it can be found.
+func (f FuncIO) Path() string {
+	if len(f.In) > 0 {
+		return f.In[0].Path
+	}
+	if len(f.Out) > 0 {
+		return f.Out[0].Path
+	}
+	return ""
+}
+
 // Msg represents a message.
 type Msg struct {
 	Type  MsgType        `json:"-"`
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MsgToJSONValue converts message to value that encoding/json can marshal.
+// Not all messages implement json.Marshaler and map's implementation is made for humans, so it must be used instead.
+// Structs are maps at runtime so both become objects, nil message (none of maybe) becomes null.
+func MsgToJSONValue(msg Msg) any {
+	if msg == nil {
+		return nil
+	}
+
+	switch msg.Type() {
+	case BoolMsgType:
+		return msg.Bool()
+	case IntMsgType:
+		return msg.Int()
+	case FloatMsgType:
+		return msg.Float()
+	case StrMsgType:
+		return msg.Str()
+	case ListMsgType:
+		list := make([]any, len(msg.List()))
+		for i, el := range msg.List() {
+			list[i] = MsgToJSONValue(el)
+		}
+		return list
+	case MapMsgType:
+		m := make(map[string]any, len(msg.Map()))
+		for k, v := range msg.Map() {
+			m[k] = MsgToJSONValue(v)
+		}
+		return m
+	}
+
+	return nil
+}
+
+// MsgFromJSONValue converts value decoded by encoding/json to message.
+// Decoder should use json.Decoder.UseNumber: then numbers without fraction and exponent become ints,
+// other numbers become floats. Arrays become lists, objects become maps and null becomes nil message.
+func MsgFromJSONValue(v any) (Msg, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return NewBoolMsg(v), nil
+	case string:
+		return NewStrMsg(v), nil
+	case float64:
+		return NewFloatMsg(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return NewIntMsg(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return NewFloatMsg(f), nil
+	case []any:
+		list := make([]Msg, len(v))
+		for i, el := range v {
+			msg, err := MsgFromJSONValue(el)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = msg
+		}
+		return NewListMsg(list...), nil
+	case map[string]any:
+		m := make(map[string]Msg, len(v))
+		for k, el := range v {
+			msg, err := MsgFromJSONValue(el)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = msg
+		}
+		return NewMapMsg(m), nil
+	}
+	return nil, fmt.Errorf("unsupported json value: %v", v)
+}
@@ -0,0 +1,36 @@
+package runtime
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgJSONValue(t *testing.T) {
+	msg := NewMapMsg(map[string]Msg{
+		"b":    NewBoolMsg(true),
+		"i":    NewIntMsg(42),
+		"f":    NewFloatMsg(1.5),
+		"s":    NewStrMsg("hi"),
+		"l":    NewListMsg(NewIntMsg(1), nil),
+		"none": nil,
+	})
+
+	bb, err := json.Marshal(MsgToJSONValue(msg))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"b": true, "i": 42, "f": 1.5, "s": "hi", "l": [1, null], "none": null}`, string(bb))
+
+	decoder := json.NewDecoder(strings.NewReader(string(bb)))
+	decoder.UseNumber()
+	var v any
+	require.NoError(t, decoder.Decode(&v))
+
+	got, err := MsgFromJSONValue(v)
+	require.NoError(t, err)
+	require.Equal(t, msg, got)
+
+	_, err = MsgFromJSONValue(json.Number("1e999"))
+	require.Error(t, err)
+}
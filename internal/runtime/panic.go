@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrPanic = errors.New("panic")
+
+// Panic stops the program. Run returns *PanicError with the given message
+// and path of the node whose func called Panic.
+func Panic(ctx context.Context, msg Msg) {
+	path, _ := ctx.Value("node").(string)
+	if report, ok := ctx.Value("panic").(func(string, Msg)); ok {
+		report(path, msg)
+	}
+}
+
+// PanicError describes which node has stopped the program and why.
+type PanicError struct {
+	Msg   Msg
+	Path  string       // Path of the node that panicked
+	Stack []StackFrame // From the node that panicked up to the root
+}
+
+// StackFrame is a node that panicked or one of the nodes it's nested in.
+type StackFrame struct {
+	Path     string
+	Location SourceLocation
+}
+
+func newPanicError(msg Msg, path string, sourceMap SourceMap) *PanicError {
+	err := &PanicError{Msg: msg, Path: path}
+
+	for p := path; p != ""; {
+		err.Stack = append(err.Stack, StackFrame{Path: p, Location: sourceMap[p]})
+		i := strings.LastIndexByte(p, '/')
+		if i == -1 {
+			break
+		}
+		p = p[:i]
+	}
+	err.Stack = append(err.Stack, StackFrame{Path: "main", Location: sourceMap["main"]})
+
+	return err
+}
+
+func (e *PanicError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%v: %v", ErrPanic, ErrorText(e.Msg))
+
+	for _, frame := range e.Stack {
+		if frame.Location.File == "" {
+			fmt.Fprintf(&b, "\n\t%v", frame.Path)
+			continue
+		}
+		fmt.Fprintf(&b, "\n\t%v at %v (%v)", frame.Path, frame.Location, frame.Location.Component)
+	}
+
+	return b.String()
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrPanic
+}
+
+// ErrorText renders message of the std error type as "text: child text: ..." chain.
+// Messages of other types are rendered as is.
+func ErrorText(msg Msg) string {
+	if msg == nil {
+		return "<nil>"
+	}
+
+	if msg.Type() != MapMsgType {
+		return msg.String()
+	}
+
+	text, ok := msg.Map()["text"]
+	if !ok || text.Type() != StrMsgType {
+		return msg.String()
+	}
+
+	child, ok := msg.Map()["child"]
+	if !ok || child == nil {
+		return text.Str()
+	}
+
+	return text.Str() + ": " + ErrorText(child)
+}
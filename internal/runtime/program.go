@@ -3,12 +3,16 @@ package runtime
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type Program struct {
 	Ports       Ports
 	Connections []Connection
 	Funcs       []FuncCall
+	SourceMap   SourceMap
+	// StopIsExitCode means int message sent to :stop is the exit code of the program.
+	StopIsExitCode bool
 }
 
 type PortAddr struct {
@@ -21,8 +25,44 @@ func (p PortAddr) String() string {
 	return fmt.Sprintf("%v:%v[%v]", p.Path, p.Port, p.Idx)
 }
 
+// NodePath returns path of the node that port belongs to. Ports of the root component belong to "main".
+func (p PortAddr) NodePath() string {
+	path := strings.TrimSuffix(p.Path, "/in")
+	path = strings.TrimSuffix(path, "/out")
+	if path == "in" || path == "out" {
+		return "main"
+	}
+	return path
+}
+
 type Ports map[PortAddr]chan Msg
 
+// SourceLocation points to the place in source code where node is declared.
+type SourceLocation struct {
+	File         string
+	Line, Column int
+	Component    string // Component that node is instance of
+}
+
+// String returns location in "file:line:column" form or empty string if location is unknown.
+func (l SourceLocation) String() string {
+	if l.File == "" {
+		return ""
+	}
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%v:%v:%v", l.File, l.Line, l.Column)
+}
+
+// SourceMap maps node paths to their locations in source code.
+type SourceMap map[string]SourceLocation
+
+// Lookup returns location of the node that port belongs to.
+func (s SourceMap) Lookup(addr PortAddr) SourceLocation {
+	return s[addr.NodePath()]
+}
+
 type Connection struct {
 	Sender    chan Msg
 	Receivers []chan Msg
@@ -32,12 +72,25 @@ type Connection struct {
 type ConnectionMeta struct {
 	SenderPortAddr    PortAddr
 	ReceiverPortAddrs []PortAddr
+	SenderLocation    SourceLocation
+	ReceiverLocations []SourceLocation // Same order as ReceiverPortAddrs
+}
+
+// ReceiverLocation returns source location of the given receiver's node.
+func (m ConnectionMeta) ReceiverLocation(addr PortAddr) SourceLocation {
+	for i, receiver := range m.ReceiverPortAddrs {
+		if receiver == addr && i < len(m.ReceiverLocations) {
+			return m.ReceiverLocations[i]
+		}
+	}
+	return SourceLocation{}
 }
 
 type FuncCall struct {
 	Ref       string
 	IO        FuncIO
 	ConfigMsg Msg
+	NodePath  string // Path of the node that calls func
 }
 
 type FuncIO struct {
@@ -5,20 +5,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"sync"
 )
 
 type Runtime struct {
 	connector  Connector
 	funcRunner FuncRunner
+	watchers   []Watcher
+}
+
+// Connector moves messages from senders to receivers until context is done.
+// It only takes messages from senders that gate lets through and reports every delivery to it.
+type Connector interface {
+	Connect(ctx context.Context, conns []Connection, gate *Gate)
+}
+
+// Watcher runs alongside the program until context is done.
+// If it returns an error, program is stopped and Run returns that error.
+type Watcher interface {
+	Watch(ctx context.Context, prog Program) error
 }
 
 var ErrNilDeps = errors.New("runtime deps nil")
 
-func New(connector Connector, funcRunner FuncRunner) Runtime {
+func New(connector Connector, funcRunner FuncRunner, watchers ...Watcher) Runtime {
 	return Runtime{
 		connector:  connector,
 		funcRunner: funcRunner,
+		watchers:   watchers,
 	}
 }
 
@@ -29,7 +45,9 @@ var (
 	ErrFuncRunner        = errors.New("func runner")
 )
 
-func (r Runtime) Run(ctx context.Context, prog Program) error {
+// Run runs the program until it sends a message to :stop or fails.
+// See ShutdownPolicy for what happens when the process is asked to stop.
+func (r Runtime) Run(ctx context.Context, prog Program, shutdown ShutdownPolicy) error {
 	enter := prog.Ports[PortAddr{Path: "in", Port: "start"}]
 	if enter == nil {
 		return ErrStartPortNotFound
@@ -45,24 +63,64 @@ func (r Runtime) Run(ctx context.Context, prog Program) error {
 		return fmt.Errorf("%w: %v", ErrFuncRunner, err)
 	}
 
+	signalPorts, err := r.funcRunner.signalPorts(prog.Funcs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFuncRunner, err)
+	}
+
 	cancelableCtx, cancel := context.WithCancel(ctx)
+	gate := NewGate(prog.Connections, prog.Funcs)
+
+	var (
+		errOnce sync.Once
+		runErr  error
+	)
+	// first error wins, everything after it is a consequence of stopping the program
+	stop := func(err error) {
+		errOnce.Do(func() { runErr = err })
+		cancel()
+	}
 
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 
+	funcCtx := context.WithValue(
+		cancelableCtx,
+		"panic", //nolint:staticcheck // SA1029
+		func(path string, msg Msg) {
+			stop(newPanicError(msg, path, prog.SourceMap))
+		},
+	)
+	funcCtx = context.WithValue(
+		funcCtx,
+		"exit", //nolint:staticcheck // SA1029
+		func(code int64) {
+			stop(exitError(code))
+		},
+	)
+
+	if len(shutdown.Signals) > 0 {
+		subscribers := newSignalSubscribers(signalPorts)
+		funcCtx = context.WithValue(funcCtx, "signals", subscribers.subscribe) //nolint:staticcheck // SA1029
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, shutdown.Signals...)
+		defer signal.Stop(signals)
+
+		wg.Add(1)
+		go func() {
+			subscribers.handleSignals(cancelableCtx, signals, shutdown, gate, stop)
+			wg.Done()
+		}()
+	}
+
 	go func() {
-		funcRun(
-			context.WithValue(
-				cancelableCtx,
-				"cancel", //nolint:staticcheck // SA1029
-				cancel,
-			),
-		)
+		funcRun(funcCtx)
 		wg.Done()
 	}()
 
 	go func() {
-		r.connector.Connect(cancelableCtx, prog.Connections)
+		r.connector.Connect(cancelableCtx, prog.Connections, gate)
 		wg.Done()
 	}()
 
@@ -71,11 +129,29 @@ func (r Runtime) Run(ctx context.Context, prog Program) error {
 	}()
 
 	go func() {
-		<-exit
-		cancel()
+		select {
+		case <-cancelableCtx.Done():
+		case msg := <-exit:
+			if prog.StopIsExitCode {
+				stop(exitError(msg.Int()))
+				return
+			}
+			cancel()
+		}
 	}()
 
+	wg.Add(len(r.watchers))
+	for i := range r.watchers {
+		watcher := r.watchers[i]
+		go func() {
+			if err := watcher.Watch(cancelableCtx, prog); err != nil {
+				stop(err)
+			}
+			wg.Done()
+		}()
+	}
+
 	wg.Wait()
 
-	return nil
+	return runErr
 }
@@ -0,0 +1,252 @@
+package runtime
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	goruntime "runtime"
+)
+
+// SeededConnector moves messages from a single loop, one step at a time.
+// On every step it lets funcs settle and then picks one of the possible steps
+// (take message from sender or pass it to one of the receivers) in a random order defined by seed.
+// Ordering is best-effort: choice between steps that are ready is the same for the same seed,
+// which helps to explore different orders of events, but runs are not reproducible.
+// Funcs are goroutines that loop doesn't control, so a func that does real work (IO, sleep, long computation)
+// makes its step ready later than others and the order depends on the Go scheduler again.
+type SeededConnector struct {
+	listener EventListener
+	seed     int64
+}
+
+// settleRounds is how many times the loop yields before each step so woken up funcs can do their work.
+const settleRounds = 8
+
+// inFlight is a message that is taken from sender but not yet passed to all receivers.
+type inFlight struct {
+	msgs      []Msg // intercepted message for every receiver
+	remaining []int // indexes of receivers that haven't got the message yet
+}
+
+// step is either taking message from sender (receiver == -1) or passing it to the receiver.
+type step struct {
+	conn     int
+	receiver int
+}
+
+// seededLoop is the state of a single Connect call.
+type seededLoop struct {
+	listener          EventListener
+	gate              *Gate
+	conns             []Connection
+	flights           []*inFlight
+	receiversForEvent []map[PortAddr]struct{}
+	// senders maps sender channels to their connections.
+	// Intermediate ports are both receivers and senders and nobody but the loop reads them,
+	// so instead of sending to such port loop passes the message to the next connection directly.
+	senders map[chan Msg]int
+}
+
+func (c SeededConnector) Connect(ctx context.Context, conns []Connection, gate *Gate) {
+	loop := seededLoop{
+		listener:          c.listener,
+		gate:              gate,
+		conns:             conns,
+		flights:           make([]*inFlight, len(conns)),
+		receiversForEvent: make([]map[PortAddr]struct{}, len(conns)),
+		senders:           make(map[chan Msg]int, len(conns)),
+	}
+	for i, conn := range conns {
+		loop.receiversForEvent[i] = getReceiversForEvent(conn)
+		loop.senders[conn.Sender] = i
+	}
+
+	rnd := rand.New(rand.NewSource(c.seed)) //nolint:gosec // reproducibility is the point
+
+	for {
+		for i := 0; i < settleRounds; i++ {
+			goruntime.Gosched()
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		steps := make([]step, 0, len(conns))
+		for i, flight := range loop.flights {
+			if flight == nil {
+				if gate.Open(conns[i].Sender) {
+					steps = append(steps, step{conn: i, receiver: -1})
+				}
+				continue
+			}
+			for _, j := range flight.remaining {
+				steps = append(steps, step{conn: i, receiver: j})
+			}
+		}
+
+		rnd.Shuffle(len(steps), func(i, j int) {
+			steps[i], steps[j] = steps[j], steps[i]
+		})
+
+		if !loop.tryStep(steps) {
+			loop.waitStep(ctx, steps)
+		}
+	}
+}
+
+// tryStep makes the first step that can be done without blocking. It returns false if there's none.
+func (l seededLoop) tryStep(steps []step) bool {
+	for _, s := range steps {
+		conn := l.conns[s.conn]
+
+		if s.receiver == -1 {
+			select {
+			case msg := <-conn.Sender:
+				l.flights[s.conn] = l.send(s.conn, msg)
+				return true
+			default:
+				continue
+			}
+		}
+
+		flight := l.flights[s.conn]
+		receiver := conn.Receivers[s.receiver]
+		msg := flight.msgs[s.receiver]
+
+		// buffered messages must be taken first to preserve the order
+		if next, ok := l.senders[receiver]; ok && len(receiver) == 0 && l.flights[next] == nil {
+			l.flights[s.conn] = l.receive(s.conn, s.receiver)
+			l.flights[next] = l.send(next, msg)
+			return true
+		}
+
+		select {
+		case receiver <- msg:
+			l.flights[s.conn] = l.receive(s.conn, s.receiver)
+			return true
+		default:
+		}
+	}
+
+	return false
+}
+
+// waitStep blocks until one of the steps happens. It's used when funcs are waiting for something external.
+func (l seededLoop) waitStep(ctx context.Context, steps []step) {
+	// draining closes some of the steps so they must be collected again
+	var draining <-chan struct{}
+	if !l.gate.isDraining() {
+		draining = l.gate.Draining()
+	}
+
+	cases := make([]reflect.SelectCase, 0, len(steps)+2)
+	cases = append(cases, reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ctx.Done()),
+	}, reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(draining),
+	})
+	for _, s := range steps {
+		conn := l.conns[s.conn]
+		if s.receiver == -1 {
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(conn.Sender),
+			})
+			continue
+		}
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectSend,
+			Chan: reflect.ValueOf(conn.Receivers[s.receiver]),
+			Send: reflect.ValueOf(&l.flights[s.conn].msgs[s.receiver]).Elem(),
+		})
+	}
+
+	chosen, recv, _ := reflect.Select(cases)
+	if chosen < 2 {
+		return
+	}
+
+	s := steps[chosen-2]
+	if s.receiver == -1 {
+		msg, _ := recv.Interface().(Msg)
+		l.flights[s.conn] = l.send(s.conn, msg)
+		return
+	}
+
+	l.flights[s.conn] = l.receive(s.conn, s.receiver)
+}
+
+// send notifies listener that message has left the sender and is pending for every receiver.
+func (l seededLoop) send(connIdx int, msg Msg) *inFlight {
+	conn := l.conns[connIdx]
+
+	l.gate.Taken(conn.Sender, conn.Receivers)
+
+	msg = l.listener.Send(Event{
+		Type: MessageSentEvent,
+		MessageSent: &EventMessageSent{
+			SenderPortAddr:    conn.Meta.SenderPortAddr,
+			ReceiverPortAddrs: l.receiversForEvent[connIdx],
+			SenderLocation:    conn.Meta.SenderLocation,
+		},
+	}, msg)
+
+	flight := &inFlight{
+		msgs:      make([]Msg, len(conn.Receivers)),
+		remaining: make([]int, len(conn.Receivers)),
+	}
+
+	for i := range conn.Receivers {
+		msg = l.listener.Send(Event{
+			Type: MessagePendingEvent,
+			MessagePending: &EventMessagePending{
+				Meta:             conn.Meta,
+				ReceiverPortAddr: conn.Meta.ReceiverPortAddrs[i],
+			},
+		}, msg)
+		flight.msgs[i] = msg
+		flight.remaining[i] = i
+	}
+
+	return flight
+}
+
+// receive notifies listener that receiver has got the message
+// and returns what's left in flight or nil if all receivers are done.
+func (l seededLoop) receive(connIdx int, receiver int) *inFlight {
+	conn := l.conns[connIdx]
+	flight := l.flights[connIdx]
+
+	l.gate.Delivered(conn.Sender, conn.Receivers[receiver])
+
+	l.listener.Send(Event{
+		Type: MessageReceivedEvent,
+		MessageReceived: &EventMessageReceived{
+			Meta:             conn.Meta,
+			ReceiverPortAddr: conn.Meta.ReceiverPortAddrs[receiver],
+		},
+	}, flight.msgs[receiver])
+
+	for i, j := range flight.remaining {
+		if j == receiver {
+			flight.remaining = append(flight.remaining[:i], flight.remaining[i+1:]...)
+			break
+		}
+	}
+
+	if len(flight.remaining) == 0 {
+		return nil
+	}
+
+	return flight
+}
+
+func NewSeededConnector(lis EventListener, seed int64) SeededConnector {
+	return SeededConnector{
+		listener: lis,
+		seed:     seed,
+	}
+}
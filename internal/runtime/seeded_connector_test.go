@@ -0,0 +1,95 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deliveryOrder connects n senders that already have messages to a single receiver
+// and returns the order in which receiver got them.
+// Every channel is ready from the beginning, so only connector decides the order.
+func deliveryOrder(t *testing.T, connector Connector, n int) []int64 {
+	t.Helper()
+
+	receiver := make(chan Msg, n)
+	conns := make([]Connection, n)
+	for i := range conns {
+		sender := make(chan Msg, 1)
+		sender <- NewIntMsg(int64(i))
+		conns[i] = Connection{
+			Sender:    sender,
+			Receivers: []chan Msg{receiver},
+			Meta: ConnectionMeta{
+				SenderPortAddr:    PortAddr{Path: "sender", Idx: uint8(i)},
+				ReceiverPortAddrs: []PortAddr{{Path: "receiver"}},
+			},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		connector.Connect(ctx, conns, NewGate(conns, nil))
+		close(done)
+	}()
+
+	order := make([]int64, n)
+	for i := range order {
+		order[i] = (<-receiver).Int()
+	}
+
+	cancel()
+	<-done
+
+	return order
+}
+
+func TestSeededConnector_SameSeedSameOrder(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		want := deliveryOrder(t, NewSeededConnector(EmptyListener{}, seed), 8)
+		for i := 0; i < 10; i++ {
+			require.Equal(t, want, deliveryOrder(t, NewSeededConnector(EmptyListener{}, seed), 8), "seed %d", seed)
+		}
+	}
+}
+
+func TestSeededConnector_SeedDefinesOrder(t *testing.T) {
+	orders := map[string]struct{}{}
+	for seed := int64(0); seed < 10; seed++ {
+		order := deliveryOrder(t, NewSeededConnector(EmptyListener{}, seed), 8)
+		require.ElementsMatch(t, []int64{0, 1, 2, 3, 4, 5, 6, 7}, order)
+		orders[fmt.Sprint(order)] = struct{}{}
+	}
+
+	// 8! orders are possible, it's very unlikely that different seeds give only a few of them
+	require.Greater(t, len(orders), 5)
+}
+
+// Messages of the same sender must keep their order whatever the seed is.
+func TestSeededConnector_SenderOrder(t *testing.T) {
+	sender := make(chan Msg, 3)
+	receiver := make(chan Msg, 3)
+	for i := 0; i < 3; i++ {
+		sender <- NewIntMsg(int64(i))
+	}
+
+	conns := []Connection{{
+		Sender:    sender,
+		Receivers: []chan Msg{receiver},
+		Meta: ConnectionMeta{
+			SenderPortAddr:    PortAddr{Path: "sender"},
+			ReceiverPortAddrs: []PortAddr{{Path: "receiver"}},
+		},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go NewSeededConnector(EmptyListener{}, 42).Connect(ctx, conns, NewGate(conns, nil))
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, int64(i), (<-receiver).Int())
+	}
+}
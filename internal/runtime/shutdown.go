@@ -0,0 +1,420 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var ErrInterrupted = errors.New("interrupted")
+
+// InterruptError is returned by Run when the program was stopped because of the signal.
+type InterruptError struct {
+	Signal os.Signal
+	Reason string // Empty if program was stopped as soon as messages in flight were delivered
+}
+
+func (e *InterruptError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%v: %v", ErrInterrupted, e.Signal)
+	}
+	return fmt.Sprintf("%v: %v, %v", ErrInterrupted, e.Signal, e.Reason)
+}
+
+func (e *InterruptError) Unwrap() error {
+	return ErrInterrupted
+}
+
+// ExitCode follows shell convention of 128 plus signal number, e.g. 130 for SIGINT.
+func (e *InterruptError) ExitCode() int {
+	if sig, ok := e.Signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return 1
+}
+
+// ShutdownPolicy defines what runtime does when the process receives one of the signals.
+// Connector stops taking new messages from funcs and only delivers messages that are in flight.
+// If program has os.Signal nodes, runtime sends them name of the signal and keeps connections
+// that go after them working, so program can run its cleanup and send a message to :stop in DrainTimeout.
+// Otherwise program is stopped as soon as messages in flight are delivered or DrainTimeout expires.
+// If the signal is received again program is stopped right away.
+// Run returns *InterruptError in all these cases except one: if program has handled the signal
+// and sent a message to :stop in time, Run returns the same as if there was no signal (nil on success).
+// Zero value means signals are not handled.
+type ShutdownPolicy struct {
+	Signals      []os.Signal
+	DrainTimeout time.Duration
+}
+
+// DefaultShutdownPolicy handles SIGINT and SIGTERM and gives program 5 seconds to stop.
+func DefaultShutdownPolicy() ShutdownPolicy {
+	return ShutdownPolicy{
+		Signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+		DrainTimeout: 5 * time.Second,
+	}
+}
+
+// Signals returns channel that receives names of the signals (e.g. "interrupt" or "terminated")
+// process gets while the program is running. It returns nil if runtime doesn't handle signals.
+// Out is the port func sends signals to, connections that go after it keep working while program drains.
+// Funcs must call it once at the beginning. Creators of such funcs should implement SignalFunc,
+// otherwise signals that come before the call are handled as if nobody subscribed.
+func Signals(ctx context.Context, out chan Msg) <-chan string {
+	subscribe, ok := ctx.Value("signals").(func(chan Msg) <-chan string)
+	if !ok {
+		return nil
+	}
+	return subscribe(out)
+}
+
+// SignalFunc is implemented by creators of funcs that receive signals with Signals.
+// Runtime subscribes their ports while funcs are created, before the program starts.
+type SignalFunc interface {
+	// SignalPort returns the port func passes to Signals.
+	SignalPort(io FuncIO) (chan Msg, error)
+}
+
+// Gate decides which senders connector can take messages from and counts deliveries that are in flight.
+// It's open for everybody until the program starts draining.
+// Connectors must call Taken for every message they take from sender and Delivered for every receiver that got it.
+type Gate struct {
+	conns []Connection
+	funcs []FuncCall
+	// internal are ports that are both receivers and senders (e.g. ports of sub-components),
+	// messages in them are still in flight so they're never closed.
+	internal map[chan Msg]struct{}
+	// sources are outports of funcs without inports (e.g. constants) and internal ports that only they send to.
+	// Their messages are not in flight: they don't come from the rest of the network, so receivers may never want them.
+	sources map[chan Msg]struct{}
+
+	mu       sync.Mutex
+	draining chan struct{}
+	isDrain  bool
+	cleanup  map[chan Msg]struct{}
+	pending  int
+	changed  chan struct{} // closed and replaced every time pending changes while draining
+}
+
+// Draining returns channel that is closed when program starts draining.
+func (g *Gate) Draining() <-chan struct{} {
+	return g.draining
+}
+
+func (g *Gate) isDraining() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.isDrain
+}
+
+// Open tells whether connector can take messages from the sender.
+func (g *Gate) Open(sender chan Msg) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.isDrain {
+		return true
+	}
+	if _, ok := g.internal[sender]; ok {
+		return true
+	}
+	_, ok := g.cleanup[sender]
+	return ok
+}
+
+// Taken must be called when connector has taken message from the sender.
+func (g *Gate) Taken(sender chan Msg, receivers []chan Msg) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, fromSource := g.sources[sender]
+	for _, receiver := range receivers {
+		if _, ok := g.sources[receiver]; ok {
+			continue
+		}
+		if _, ok := g.internal[receiver]; fromSource && !ok {
+			continue
+		}
+		g.pending++
+	}
+
+	_, fromInternal := g.internal[sender]
+	if fromInternal && !fromSource { // delivery to this port is done now
+		g.pending--
+	}
+
+	g.notify()
+}
+
+// Delivered must be called when receiver has got the message from the sender.
+func (g *Gate) Delivered(sender, receiver chan Msg) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.internal[receiver]; ok { // delivery is done when message is taken from this port
+		return
+	}
+	if _, ok := g.sources[sender]; ok {
+		return
+	}
+
+	g.pending--
+	g.notify()
+}
+
+func (g *Gate) notify() {
+	if !g.isDrain {
+		return
+	}
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// drain closes the gate for everybody except internal ports and connections that go after the given senders.
+func (g *Gate) drain(cleanupSenders []chan Msg) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.isDrain {
+		return
+	}
+
+	g.cleanup = g.reachable(cleanupSenders)
+	g.isDrain = true
+	close(g.draining)
+}
+
+// inFlight returns number of deliveries that are not done yet and channel that is closed when it changes.
+func (g *Gate) inFlight() (int, <-chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.pending, g.changed
+}
+
+// reachable returns senders of all connections that messages from the given senders can go through.
+// Messages go through funcs too: everything func sends can be caused by what it has received.
+func (g *Gate) reachable(senders []chan Msg) map[chan Msg]struct{} {
+	connBySender := make(map[chan Msg]Connection, len(g.conns))
+	for _, conn := range g.conns {
+		connBySender[conn.Sender] = conn
+	}
+
+	funcOuts := map[chan Msg][]chan Msg{} // func inport -> all outports of that func
+	for _, call := range g.funcs {
+		var outs []chan Msg
+		for _, slots := range call.IO.Out {
+			outs = append(outs, slots...)
+		}
+		for _, slots := range call.IO.In {
+			for _, in := range slots {
+				funcOuts[in] = append(funcOuts[in], outs...)
+			}
+		}
+	}
+
+	visited := map[chan Msg]struct{}{}
+	queue := slices.Clone(senders)
+	for len(queue) > 0 {
+		sender := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[sender]; ok {
+			continue
+		}
+		visited[sender] = struct{}{}
+
+		conn, ok := connBySender[sender]
+		if !ok {
+			continue
+		}
+		for _, receiver := range conn.Receivers {
+			queue = append(queue, receiver) // receiver can be internal port that is also a sender
+			queue = append(queue, funcOuts[receiver]...)
+		}
+	}
+
+	return visited
+}
+
+// NewGate creates gate for the program. Every Run creates its own gate and passes it to connector.
+func NewGate(conns []Connection, funcs []FuncCall) *Gate {
+	internal := map[chan Msg]struct{}{}
+
+	senders := make(map[chan Msg]struct{}, len(conns))
+	for _, conn := range conns {
+		senders[conn.Sender] = struct{}{}
+	}
+	for _, conn := range conns {
+		for _, receiver := range conn.Receivers {
+			if _, ok := senders[receiver]; ok {
+				internal[receiver] = struct{}{}
+			}
+		}
+	}
+
+	return &Gate{
+		conns:    conns,
+		funcs:    funcs,
+		internal: internal,
+		sources:  sources(conns, funcs, internal),
+		draining: make(chan struct{}),
+		changed:  make(chan struct{}),
+	}
+}
+
+func sources(conns []Connection, funcs []FuncCall, internal map[chan Msg]struct{}) map[chan Msg]struct{} {
+	sources := map[chan Msg]struct{}{}
+	for _, call := range funcs {
+		if len(call.IO.In) > 0 {
+			continue
+		}
+		for _, slots := range call.IO.Out {
+			for _, out := range slots {
+				sources[out] = struct{}{}
+			}
+		}
+	}
+
+	upstream := map[chan Msg][]chan Msg{} // internal port -> senders that send to it
+	for _, conn := range conns {
+		for _, receiver := range conn.Receivers {
+			if _, ok := internal[receiver]; ok {
+				upstream[receiver] = append(upstream[receiver], conn.Sender)
+			}
+		}
+	}
+
+	// internal ports can be chained so we repeat until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for port, senders := range upstream {
+			if _, ok := sources[port]; ok {
+				continue
+			}
+			all := true
+			for _, sender := range senders {
+				if _, ok := sources[sender]; !ok {
+					all = false
+					break
+				}
+			}
+			if all {
+				sources[port] = struct{}{}
+				changed = true
+			}
+		}
+	}
+
+	return sources
+}
+
+// signalSubscribers are channels of os.Signal nodes and ports they send signals to.
+type signalSubscribers struct {
+	mu       sync.Mutex
+	subs     []chan string
+	outs     []chan Msg
+	reserved map[chan Msg]chan string // subscribed in advance, not yet taken by Signals
+}
+
+// newSignalSubscribers subscribes the given ports in advance so signals are not missed
+// while funcs are starting. Signals called with these ports returns the existing subscription.
+func newSignalSubscribers(outs []chan Msg) *signalSubscribers {
+	s := &signalSubscribers{reserved: make(map[chan Msg]chan string, len(outs))}
+	for _, out := range outs {
+		s.reserved[out] = s.add(out)
+	}
+	return s
+}
+
+func (s *signalSubscribers) subscribe(out chan Msg) <-chan string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.reserved[out]; ok {
+		delete(s.reserved, out)
+		return ch
+	}
+	return s.add(out)
+}
+
+func (s *signalSubscribers) add(out chan Msg) chan string {
+	ch := make(chan string, 1)
+	s.subs = append(s.subs, ch)
+	s.outs = append(s.outs, out)
+	return ch
+}
+
+// notify sends signal to every subscriber and returns false if there's none.
+func (s *signalSubscribers) notify(sig os.Signal) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.subs {
+		select {
+		case ch <- sig.String():
+		default: // previous signal is not handled yet
+		}
+	}
+	return len(s.subs) > 0
+}
+
+func (s *signalSubscribers) senders() []chan Msg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.outs)
+}
+
+// handleSignals waits for the first signal, starts draining and then gives the program time to stop.
+func (s *signalSubscribers) handleSignals(
+	ctx context.Context,
+	signals <-chan os.Signal,
+	policy ShutdownPolicy,
+	gate *Gate,
+	stop func(error),
+) {
+	var sig os.Signal
+	select {
+	case <-ctx.Done():
+		return
+	case sig = <-signals:
+	}
+
+	gate.drain(s.senders())
+
+	timer := time.NewTimer(policy.DrainTimeout)
+	defer timer.Stop()
+
+	// nobody handles the signal so program is stopped as soon as it's drained
+	if !s.notify(sig) {
+		for {
+			pending, changed := gate.inFlight()
+			if pending == 0 {
+				stop(&InterruptError{Signal: sig})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			case <-signals:
+				stop(&InterruptError{Signal: sig, Reason: "received twice"})
+				return
+			case <-timer.C:
+				stop(&InterruptError{Signal: sig, Reason: fmt.Sprintf("messages weren't delivered in %v", policy.DrainTimeout)})
+				return
+			}
+		}
+	}
+
+	select {
+	case <-ctx.Done(): // program has stopped in time
+	case <-signals:
+		stop(&InterruptError{Signal: sig, Reason: "received twice"})
+	case <-timer.C:
+		stop(&InterruptError{Signal: sig, Reason: fmt.Sprintf("program didn't stop in %v", policy.DrainTimeout)})
+	}
+}
@@ -0,0 +1,148 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGate(t *testing.T) {
+	var (
+		start    = make(chan Msg)
+		sub      = make(chan Msg) // port of sub-component, both receiver and sender
+		funcIn   = make(chan Msg)
+		funcOut  = make(chan Msg)
+		sigOut   = make(chan Msg)
+		cleanIn  = make(chan Msg)
+		cleanOut = make(chan Msg)
+		stop     = make(chan Msg)
+		constOut = make(chan Msg)
+	)
+
+	gate := NewGate(
+		[]Connection{
+			{Sender: start, Receivers: []chan Msg{sub}},
+			{Sender: sub, Receivers: []chan Msg{funcIn}},
+			{Sender: funcOut, Receivers: []chan Msg{stop}},
+			{Sender: sigOut, Receivers: []chan Msg{cleanIn}},
+			{Sender: cleanOut, Receivers: []chan Msg{stop}},
+			{Sender: constOut, Receivers: []chan Msg{funcIn}},
+		},
+		[]FuncCall{
+			{IO: FuncIO{In: FuncPorts{"data": {funcIn}}, Out: FuncPorts{"res": {funcOut}}}},
+			{IO: FuncIO{In: FuncPorts{"data": {cleanIn}}, Out: FuncPorts{"res": {cleanOut}}}},
+			{IO: FuncIO{Out: FuncPorts{"msg": {constOut}}}},
+		},
+	)
+
+	for _, sender := range []chan Msg{start, sub, funcOut, sigOut, cleanOut} {
+		require.True(t, gate.Open(sender))
+	}
+
+	// message in sub-component's port is still in flight
+	gate.Taken(start, []chan Msg{sub})
+	gate.Delivered(start, sub)
+	pending, _ := gate.inFlight()
+	require.Equal(t, 1, pending)
+
+	gate.Taken(sub, []chan Msg{funcIn})
+	pending, changed := gate.inFlight()
+	require.Equal(t, 1, pending)
+
+	// constant may never be received so it's not in flight
+	gate.Taken(constOut, []chan Msg{funcIn})
+	pending, _ = gate.inFlight()
+	require.Equal(t, 1, pending)
+
+	gate.drain([]chan Msg{sigOut})
+
+	select {
+	case <-gate.Draining():
+	default:
+		t.Fatal("gate is not draining")
+	}
+
+	require.False(t, gate.Open(start))
+	require.False(t, gate.Open(funcOut))
+	require.False(t, gate.Open(constOut))
+	require.True(t, gate.Open(sub))
+	require.True(t, gate.Open(sigOut))
+	require.True(t, gate.Open(cleanOut))
+
+	gate.Delivered(sub, funcIn)
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("change of messages in flight is not reported while draining")
+	}
+
+	pending, _ = gate.inFlight()
+	require.Zero(t, pending)
+}
+
+func TestInterruptError(t *testing.T) {
+	err := &InterruptError{Signal: os.Interrupt}
+	require.ErrorIs(t, err, ErrInterrupted)
+	require.Equal(t, "interrupted: interrupt", err.Error())
+	require.Equal(t, 130, err.ExitCode())
+
+	err = &InterruptError{Signal: syscall.SIGTERM, Reason: "received twice"}
+	require.Equal(t, "interrupted: terminated, received twice", err.Error())
+	require.Equal(t, 143, err.ExitCode())
+}
+
+type testSignalFunc struct{}
+
+func (testSignalFunc) SignalPort(io FuncIO) (chan Msg, error) {
+	return io.Out.Port("msg")
+}
+
+func (testSignalFunc) Create(FuncIO, Msg) (func(context.Context), error) {
+	return func(context.Context) {}, nil
+}
+
+// Signal that comes before func calls Signals must still reach it instead of stopping the program.
+func TestHandleSignals_BeforeSubscription(t *testing.T) {
+	out := make(chan Msg)
+	calls := []FuncCall{{Ref: "signal", IO: FuncIO{Out: FuncPorts{"msg": {out}}}}}
+
+	ports, err := MustNewFuncRunner(map[string]FuncCreator{"signal": testSignalFunc{}}).signalPorts(calls)
+	require.NoError(t, err)
+	require.Equal(t, []chan Msg{out}, ports)
+
+	subscribers := newSignalSubscribers(ports)
+	gate := NewGate(nil, calls)
+	signals := make(chan os.Signal, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		subscribers.handleSignals(ctx, signals, ShutdownPolicy{DrainTimeout: time.Minute}, gate, func(err error) {
+			stopped <- err
+		})
+		close(done)
+	}()
+
+	signals <- os.Interrupt
+	<-gate.Draining()
+
+	select {
+	case sig := <-subscribers.subscribe(out):
+		require.Equal(t, "interrupt", sig)
+	case err := <-stopped:
+		t.Fatalf("program is stopped without cleanup: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("signal is not delivered to subscriber")
+	}
+
+	// program has stopped by itself in time
+	cancel()
+	<-done
+	require.Empty(t, stopped)
+}
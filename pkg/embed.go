@@ -0,0 +1,9 @@
+package pkg
+
+import "embed"
+
+// Efs contains public packages that generated programs must be built with.
+//
+//nolint:golint
+//go:embed runtime
+var Efs embed.FS
@@ -0,0 +1,40 @@
+// Package runtime is the API for native funcs that neva modules implement in Go.
+// It only re-exports what funcs need from the internal runtime,
+// so module's Go package can be built and tested as a regular dependent of neva module.
+package runtime
+
+import (
+	"github.com/nevalang/neva/internal/runtime"
+	"github.com/nevalang/neva/internal/runtime/funcs"
+)
+
+type (
+	Msg         = runtime.Msg
+	MsgType     = runtime.MsgType
+	FuncIO      = runtime.FuncIO
+	FuncPorts   = runtime.FuncPorts
+	FuncCreator = runtime.FuncCreator
+)
+
+const (
+	UnknownMsgType = runtime.UnknownMsgType
+	BoolMsgType    = runtime.BoolMsgType
+	IntMsgType     = runtime.IntMsgType
+	FloatMsgType   = runtime.FloatMsgType
+	StrMsgType     = runtime.StrMsgType
+	ListMsgType    = runtime.ListMsgType
+	MapMsgType     = runtime.MapMsgType
+)
+
+func NewBoolMsg(b bool) Msg          { return runtime.NewBoolMsg(b) }
+func NewIntMsg(n int64) Msg          { return runtime.NewIntMsg(n) }
+func NewFloatMsg(n float64) Msg      { return runtime.NewFloatMsg(n) }
+func NewStrMsg(s string) Msg         { return runtime.NewStrMsg(s) }
+func NewListMsg(v ...Msg) Msg        { return runtime.NewListMsg(v...) }
+func NewMapMsg(m map[string]Msg) Msg { return runtime.NewMapMsg(m) }
+
+// Register adds native func to the runtime. It must be called from init function of module's native package
+// with every ref listed in the native section of the manifest, it panics if ref is already taken.
+func Register(ref string, creator FuncCreator) {
+	funcs.Register(ref, creator)
+}